@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CamundaAuthorizationSpec defines the desired state of CamundaAuthorization.
+type CamundaAuthorizationSpec struct {
+	// ClusterName is the name of the OrchestrationCluster in the same namespace to create the
+	// authorization in.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// OwnerType is the kind of the owner the permissions are granted to.
+	// +kubebuilder:validation:Enum=USER;GROUP;ROLE;CLIENT;MAPPING_RULE
+	OwnerType string `json:"ownerType"`
+
+	// OwnerID identifies the owner, e.g. the username of a user or the id of a group.
+	// +kubebuilder:validation:MinLength=1
+	OwnerID string `json:"ownerID"`
+
+	// ResourceType is the type of the resources the permissions apply to, e.g. PROCESS_DEFINITION.
+	// +kubebuilder:validation:Pattern=`^[A-Z_]+$`
+	ResourceType string `json:"resourceType"`
+
+	// ResourceID identifies the resource, e.g. the id of a process definition. * matches all resources.
+	// +kubebuilder:validation:MinLength=1
+	ResourceID string `json:"resourceID"`
+
+	// Permissions granted on the resource, e.g. READ_PROCESS_INSTANCE.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Permissions []PermissionType `json:"permissions"`
+}
+
+// PermissionType is a permission on a resource, e.g. CREATE_PROCESS_INSTANCE.
+// +kubebuilder:validation:Pattern=`^[A-Z_]+$`
+type PermissionType string
+
+// CamundaAuthorizationStatus defines the observed state of CamundaAuthorization.
+type CamundaAuthorizationStatus struct {
+	// AuthorizationKey identifies the authorization in the cluster.
+	// +optional
+	AuthorizationKey string `json:"authorizationKey,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that was last synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cauth
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Owner",type="string",JSONPath=".spec.ownerID"
+// +kubebuilder:printcolumn:name="Resource Type",type="string",JSONPath=".spec.resourceType"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CamundaAuthorization is the Schema for the camundaauthorizations API.
+// It grants a user, group, role, client or mapping rule permissions on resources of an OrchestrationCluster.
+type CamundaAuthorization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CamundaAuthorizationSpec   `json:"spec,omitempty"`
+	Status CamundaAuthorizationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CamundaAuthorizationList contains a list of CamundaAuthorization.
+type CamundaAuthorizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CamundaAuthorization `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CamundaAuthorization{}, &CamundaAuthorizationList{})
+}
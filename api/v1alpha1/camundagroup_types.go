@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CamundaGroupSpec defines the desired state of CamundaGroup.
+type CamundaGroupSpec struct {
+	// ClusterName is the name of the OrchestrationCluster in the same namespace to create the group in.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// GroupID identifies the group in the cluster.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="groupID is immutable"
+	GroupID string `json:"groupID"`
+
+	// Name is the display name of the group.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Description of the group.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Users are the usernames of the members of the group. Members added through the API or the web
+	// applications are removed.
+	// +listType=set
+	// +optional
+	Users []string `json:"users,omitempty"`
+}
+
+// CamundaGroupStatus defines the observed state of CamundaGroup.
+type CamundaGroupStatus struct {
+	// ObservedGeneration is the generation of the spec that was last synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cg
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Group ID",type="string",JSONPath=".spec.groupID"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CamundaGroup is the Schema for the camundagroups API.
+// It declares a group of an OrchestrationCluster and its members.
+type CamundaGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CamundaGroupSpec   `json:"spec,omitempty"`
+	Status CamundaGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CamundaGroupList contains a list of CamundaGroup.
+type CamundaGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CamundaGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CamundaGroup{}, &CamundaGroupList{})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition reasons reported in the Ready condition of CamundaUsers, CamundaGroups and
+// CamundaAuthorizations.
+const (
+	ReasonSynced          = "Synced"
+	ReasonSyncFailed      = "SyncFailed"
+	ReasonClusterNotFound = "ClusterNotFound"
+)
+
+// CamundaUserSpec defines the desired state of CamundaUser.
+type CamundaUserSpec struct {
+	// ClusterName is the name of the OrchestrationCluster in the same namespace to create the user in.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// Username identifies the user in the cluster.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="username is immutable"
+	Username string `json:"username"`
+
+	// Name is the display name of the user.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Email of the user.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// PasswordSecret selects the key of a Secret holding the password of the user. The password is
+	// set again whenever the Secret changes.
+	PasswordSecret corev1.SecretKeySelector `json:"passwordSecret"`
+}
+
+// CamundaUserStatus defines the observed state of CamundaUser.
+type CamundaUserStatus struct {
+	// ObservedGeneration is the generation of the spec that was last synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PasswordSecretVersion is the resource version of the password Secret the password was last set from.
+	// +optional
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cu
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Username",type="string",JSONPath=".spec.username"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CamundaUser is the Schema for the camundausers API.
+// It declares a user of an OrchestrationCluster with basic authentication.
+type CamundaUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CamundaUserSpec   `json:"spec,omitempty"`
+	Status CamundaUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CamundaUserList contains a list of CamundaUser.
+type CamundaUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CamundaUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CamundaUser{}, &CamundaUserList{})
+}
@@ -18,13 +18,16 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // OrchestrationClusterSpec defines the desired state of OrchestrationCluster.
+// +kubebuilder:validation:XValidation:rule="!has(self.standaloneGateway) || !has(self.components) || !has(self.components.broker) || self.components.broker",message="standaloneGateway requires the broker component"
 type OrchestrationClusterSpec struct {
 	// +default:value="8.7.7"
 	Version           string `json:"version"`
@@ -49,21 +52,657 @@ type OrchestrationClusterSpec struct {
 	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 
 	Database Database `json:"database"`
+
+	// Storage configures the data volume of every broker.
+	// +optional
+	Storage *Storage `json:"storage,omitempty"`
+
+	// NodeSelector constrains the brokers to nodes with matching labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity of the brokers. Defaults to a preferred pod anti-affinity that spreads the brokers
+	// across nodes, set it to {} to disable the default.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations of the brokers.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints of the brokers. Defaults to spreading the brokers evenly across zones
+	// on a best effort basis.
+	// +optional
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +listMapKey=whenUnsatisfiable
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName of the brokers.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the brokers.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
+	// DeletionPolicy controls what happens to the data volumes of the brokers when the cluster is deleted.
+	// Retain keeps the volumes, Delete removes them and Snapshot removes them after taking a VolumeSnapshot.
+	// +kubebuilder:validation:Enum=Retain;Delete;Snapshot
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Backup configures where backups of the cluster are stored. It is required to back up the
+	// cluster with an OrchestrationClusterBackup and to restore it with an OrchestrationClusterRestore.
+	// +optional
+	Backup *BackupStore `json:"backup,omitempty"`
+
+	// Exposure makes the REST API, the web applications and the gRPC API reachable from outside of
+	// the Kubernetes cluster.
+	// +optional
+	Exposure *Exposure `json:"exposure,omitempty"`
+
+	// GatewayService configures the Service clients connect to for the REST, gRPC and management APIs.
+	// +optional
+	GatewayService *GatewayService `json:"gatewayService,omitempty"`
+
+	// StandaloneGateway runs the gateways in a Deployment of their own instead of embedded in every
+	// broker. The gateway Service then routes clients to the standalone gateways.
+	// +optional
+	StandaloneGateway *StandaloneGateway `json:"standaloneGateway,omitempty"`
+
+	// Components enables or disables the components every node of the cluster runs. All components
+	// are enabled by default.
+	// +optional
+	Components *Components `json:"components,omitempty"`
+
+	// Authentication configures how users and clients authenticate against the REST API and the web
+	// applications. Basic authentication without an initial user is used if it is not set.
+	// +optional
+	Authentication *Authentication `json:"authentication,omitempty"`
+
+	// Monitoring creates a ServiceMonitor or PodMonitor that makes the Prometheus Operator scrape the
+	// metrics of the brokers and standalone gateways. It is skipped if the Prometheus Operator CRDs
+	// are not installed.
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+}
+
+// MonitorKind is the kind of the Prometheus Operator resource that scrapes the cluster.
+type MonitorKind string
+
+const (
+	// ServiceMonitorKind scrapes the pods through the endpoints of the Services of the cluster.
+	ServiceMonitorKind MonitorKind = "ServiceMonitor"
+	// PodMonitorKind scrapes the pods directly.
+	PodMonitorKind MonitorKind = "PodMonitor"
+)
+
+// Monitoring configures the monitor scraping /actuator/prometheus on the management port 9600.
+type Monitoring struct {
+	// Kind of the monitor.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	// +kubebuilder:default=ServiceMonitor
+	Kind MonitorKind `json:"kind"`
+
+	// Interval between scrapes, e.g. 30s. The interval of the Prometheus instance is used if empty.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout of a scrape, e.g. 10s. It must not exceed the interval.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// Labels are added to the monitor, e.g. the labels the monitor selector of Prometheus matches.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Relabelings are applied to the targets before they are scraped.
+	// +optional
+	Relabelings []RelabelConfig `json:"relabelings,omitempty"`
+
+	// MetricRelabelings are applied to the scraped samples before they are ingested.
+	// +optional
+	MetricRelabelings []RelabelConfig `json:"metricRelabelings,omitempty"`
+}
+
+// RelabelConfig is a Prometheus relabeling rule, see
+// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config.
+type RelabelConfig struct {
+	// SourceLabels whose values are concatenated with the separator and matched against the regex.
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// Separator of the concatenated source label values. Defaults to ;.
+	// +optional
+	Separator string `json:"separator,omitempty"`
+
+	// TargetLabel the result of a replace action is written to.
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// Regex the concatenated source label values are matched against. Defaults to (.*).
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// Replacement written to the target label, which may refer to regex groups. Defaults to $1.
+	// +optional
+	Replacement *string `json:"replacement,omitempty"`
+
+	// Action to perform. Defaults to replace.
+	// +kubebuilder:validation:Enum=replace;keep;drop;hashmod;labelmap;labeldrop;labelkeep;lowercase;uppercase;keepequal;dropequal
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// Modulus of the hashmod action.
+	// +optional
+	Modulus uint64 `json:"modulus,omitempty"`
+}
+
+// Authentication configures the authentication method of the cluster.
+// +kubebuilder:validation:XValidation:rule="!(has(self.basic) && has(self.oidc))",message="basic and oidc are mutually exclusive"
+type Authentication struct {
+	// Basic authenticates users with a username and password managed by the cluster.
+	// +optional
+	Basic *BasicAuthentication `json:"basic,omitempty"`
+
+	// OIDC authenticates users and clients with tokens of an OpenID Connect identity provider.
+	// +optional
+	OIDC *OIDCAuthentication `json:"oidc,omitempty"`
+
+	// UnprotectedAPI allows unauthenticated requests to the REST and gRPC APIs.
+	// +optional
+	UnprotectedAPI bool `json:"unprotectedAPI,omitempty"`
+
+	// Authorizations enables the checks of the permissions of authenticated users and clients.
+	// +kubebuilder:default=true
+	// +optional
+	Authorizations *bool `json:"authorizations,omitempty"`
+}
+
+// BasicAuthentication configures basic authentication.
+type BasicAuthentication struct {
+	// AdminSecret references a Secret with the keys username and password of the initial user, who
+	// is granted the admin role. The user is only created if it does not exist yet.
+	AdminSecret corev1.LocalObjectReference `json:"adminSecret"`
+
+	// Name of the initial user.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Email of the initial user.
+	// +optional
+	Email string `json:"email,omitempty"`
+}
+
+// OIDCAuthentication configures the OpenID Connect identity provider.
+type OIDCAuthentication struct {
+	// IssuerURL is the issuer of the identity provider, the configuration is discovered from it.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientID of the cluster at the identity provider.
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+
+	// ClientSecret selects the key of a Secret holding the client secret of the cluster.
+	ClientSecret corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Audiences accepted in tokens. Tokens for any audience are accepted if it is not set.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// RedirectURI the identity provider redirects users to after the login, e.g.
+	// https://camunda.example.com/sso-callback.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	RedirectURI string `json:"redirectURI,omitempty"`
+
+	// UsernameClaim is the claim holding the username of users.
+	// +optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+
+	// ClientIDClaim is the claim holding the client id of clients.
+	// +optional
+	ClientIDClaim string `json:"clientIDClaim,omitempty"`
+
+	// GroupsClaim is the claim holding the groups of users and clients.
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// AdminUsers are the usernames granted the admin role.
+	// +optional
+	AdminUsers []string `json:"adminUsers,omitempty"`
+}
+
+// Components toggles the components of the cluster. Disabled components are left out of the Spring
+// profiles, and their ports and database settings are not configured.
+type Components struct {
+	// Broker runs the broker and the gateway embedded in it. Without it the nodes only serve the
+	// enabled web applications.
+	// +kubebuilder:default=true
+	// +optional
+	Broker *bool `json:"broker,omitempty"`
+
+	// Operate runs the Operate web application.
+	// +kubebuilder:default=true
+	// +optional
+	Operate *bool `json:"operate,omitempty"`
+
+	// Tasklist runs the Tasklist web application.
+	// +kubebuilder:default=true
+	// +optional
+	Tasklist *bool `json:"tasklist,omitempty"`
+
+	// Identity runs the Identity web application.
+	// +kubebuilder:default=true
+	// +optional
+	Identity *bool `json:"identity,omitempty"`
+}
+
+// StandaloneGateway configures the Deployment of the standalone gateways.
+type StandaloneGateway struct {
+	// Replicas of the gateway Deployment. It is ignored if autoscaling is configured.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources of the gateway containers. Autoscaling on utilization requires resource requests.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Autoscaling scales the gateway Deployment with a HorizontalPodAutoscaler.
+	// +optional
+	Autoscaling *GatewayAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// GatewayAutoscaling configures the HorizontalPodAutoscaler of the standalone gateways.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not exceed maxReplicas"
+type GatewayAutoscaling struct {
+	// MinReplicas is the lower limit of gateways.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit of gateways.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization relative to the requests the
+	// autoscaler aims for. Defaults to 80 if no memory target is set either.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the average memory utilization relative to the requests
+	// the autoscaler aims for.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// GatewayService configures the client facing Service of the cluster.
+// +kubebuilder:validation:XValidation:rule="!has(self.externalTrafficPolicy) || (has(self.type) && self.type != 'ClusterIP')",message="externalTrafficPolicy requires type NodePort or LoadBalancer"
+// +kubebuilder:validation:XValidation:rule="!has(self.loadBalancerSourceRanges) || (has(self.type) && self.type == 'LoadBalancer')",message="loadBalancerSourceRanges requires type LoadBalancer"
+// +kubebuilder:validation:XValidation:rule="!((has(self.http) && has(self.http.nodePort)) || (has(self.grpc) && has(self.grpc.nodePort))) || (has(self.type) && self.type != 'ClusterIP')",message="nodePort requires type NodePort or LoadBalancer"
+type GatewayService struct {
+	// Type of the Service.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default=ClusterIP
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations are added to the Service, e.g. to request an internal load balancer from the
+	// cloud provider.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ExternalTrafficPolicy of NodePort and LoadBalancer Services. Local preserves the source IP of
+	// the clients.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IP ranges allowed to reach a LoadBalancer Service.
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// PublishNotReadyAddresses routes clients to brokers that are not ready. Set it to false to only
+	// route clients to ready brokers.
+	// +kubebuilder:default=true
+	// +optional
+	PublishNotReadyAddresses *bool `json:"publishNotReadyAddresses,omitempty"`
+
+	// HTTP is the port of the REST API and the web applications, defaults to 8080.
+	// +optional
+	HTTP *GatewayServicePort `json:"http,omitempty"`
+
+	// GRPC is the port of the gRPC API, defaults to 26500.
+	// +optional
+	GRPC *GatewayServicePort `json:"grpc,omitempty"`
+}
+
+// GatewayServicePort configures a port of the gateway Service. The management API is always
+// served on port 9600, the operator uses it to check the health of the cluster.
+type GatewayServicePort struct {
+	// Port of the Service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// NodePort of NodePort and LoadBalancer Services. A free port is allocated if unset.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+// Exposure configures either Ingress resources or Gateway API routes for the gateway Service.
+// +kubebuilder:validation:XValidation:rule="!(has(self.ingress) && has(self.gatewayAPI))",message="ingress and gatewayAPI are mutually exclusive"
+type Exposure struct {
+	// Ingress exposes the APIs with networking.k8s.io Ingress resources.
+	// +optional
+	Ingress *IngressExposure `json:"ingress,omitempty"`
+
+	// GatewayAPI exposes the APIs with HTTPRoute and GRPCRoute resources attached to existing
+	// Gateways. The Gateway API CRDs must be installed.
+	// +optional
+	GatewayAPI *GatewayAPIExposure `json:"gatewayAPI,omitempty"`
+}
+
+// IngressExposure configures one Ingress for the HTTP endpoints and one for the gRPC endpoint, as
+// ingress controllers are told the backend protocol with annotations.
+type IngressExposure struct {
+	// ClassName of the ingress controller. The default ingress class is used if empty.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// HTTP exposes the REST API and the web applications on port 8080.
+	// +optional
+	HTTP *ExposedEndpoint `json:"http,omitempty"`
+
+	// GRPC exposes the gRPC API on port 26500. Most ingress controllers need an annotation for gRPC
+	// backends, e.g. nginx.ingress.kubernetes.io/backend-protocol: GRPC, and TLS for HTTP/2.
+	// +optional
+	GRPC *ExposedEndpoint `json:"grpc,omitempty"`
+}
+
+// GatewayAPIExposure configures an HTTPRoute for the HTTP endpoints and a GRPCRoute for the gRPC
+// endpoint. TLS is terminated by the listeners of the Gateways.
+type GatewayAPIExposure struct {
+	// ParentRefs are the Gateways the routes attach to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+
+	// HTTP exposes the REST API and the web applications on port 8080.
+	// +optional
+	HTTP *ExposedEndpoint `json:"http,omitempty"`
+
+	// GRPC exposes the gRPC API on port 26500.
+	// +optional
+	GRPC *ExposedEndpoint `json:"grpc,omitempty"`
+}
+
+// GatewayParentReference references a Gateway, optionally one of its listeners.
+type GatewayParentReference struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the Gateway, defaults to the namespace of the cluster.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener to attach to, all listeners if empty.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ExposedEndpoint is the host and path an API is reachable on.
+type ExposedEndpoint struct {
+	// Host is the fully qualified domain name the API is served on.
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Path prefix of the REST API and the web applications. A path other than / is also set as the
+	// context path of the application. It is not used for gRPC, which is routed by host.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// TLSSecretName is the kubernetes.io/tls Secret with the certificate for the host. It is only
+	// used by Ingress; with the Gateway API, TLS is configured on the Gateway.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations are added to the Ingress or route, e.g. for cert-manager or the ingress controller.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// BackupStore configures the storage of cluster backups. The brokers write their backups to a
+// bucket, the secondary storage is backed up as snapshots into a repository of the database.
+// +kubebuilder:validation:XValidation:rule="has(self.s3) != has(self.gcs)",message="exactly one of s3 or gcs must be set"
+type BackupStore struct {
+	// RepositoryName is the snapshot repository in Elasticsearch or OpenSearch the secondary storage
+	// is backed up to. The repository must be registered in the database. It is ignored for postgresql.
+	// +optional
+	RepositoryName string `json:"repositoryName,omitempty"`
+
+	// S3 stores the broker backups in an S3 compatible bucket.
+	// +optional
+	S3 *S3BackupStore `json:"s3,omitempty"`
+
+	// GCS stores the broker backups in a Google Cloud Storage bucket.
+	// +optional
+	GCS *GCSBackupStore `json:"gcs,omitempty"`
+}
+
+// S3BackupStore configures an S3 compatible bucket for the broker backups.
+type S3BackupStore struct {
+	// +kubebuilder:validation:MinLength=1
+	BucketName string `json:"bucketName"`
+
+	// BasePath is prefixed to all objects, which allows several clusters to share a bucket.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint of an S3 compatible store other than AWS, e.g. MinIO.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecret references a Secret with the keys accessKey and secretKey. The default AWS
+	// credentials chain is used if it is not set, e.g. IAM roles for service accounts.
+	// +optional
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
+
+// GCSBackupStore configures a Google Cloud Storage bucket for the broker backups. The brokers
+// authenticate with the application default credentials, e.g. workload identity.
+type GCSBackupStore struct {
+	// +kubebuilder:validation:MinLength=1
+	BucketName string `json:"bucketName"`
+
+	// BasePath is prefixed to all objects, which allows several clusters to share a bucket.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+}
+
+// DeletionPolicy is the treatment of the broker data volumes when the cluster is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the data volumes, they are marked with the cluster they belonged to.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDelete deletes the data volumes.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicySnapshot takes a VolumeSnapshot of every data volume before deleting it.
+	DeletionPolicySnapshot DeletionPolicy = "Snapshot"
+)
+
+// PodDisruptionBudget configures the number of brokers that may be evicted at the same time.
+type PodDisruptionBudget struct {
+	// MaxUnavailable overrides the number of brokers that can be unavailable during a voluntary
+	// disruption. It defaults to the number of replicas a partition can lose while keeping its
+	// quorum, (replicationFactor - 1) / 2, which blocks evictions for a replication factor below 3.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Storage configures the persistent volume claim created for the data of each broker.
+// Only the size can be changed once the cluster is created, growing it expands the existing claims.
+type Storage struct {
+	// Size is the requested size of each data volume, defaults to 10Gi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the storage class of the data volumes. The default storage class is used if empty.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessModes of the data volumes, defaults to ReadWriteOnce.
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// Labels are added to the data volume claims.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the data volume claims.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// VolumeSnapshotClassName is the class of the snapshots taken with the Snapshot deletion policy.
+	// The default snapshot class is used if empty.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
 }
 
 type Database struct {
-	// +kubebuilder:validation:Enum=elasticsearch;postgresql
+	// +kubebuilder:validation:Enum=elasticsearch;opensearch;postgresql
 	Type     DatabaseType             `json:"type"`
 	UserName string                   `json:"userName,omitempty"`
 	Password corev1.SecretKeySelector `json:"password,omitempty"`
 	HostName string                   `json:"hostName,omitempty"`
+
+	// DatabaseName is the name of the PostgreSQL database, defaults to camunda.
+	// It is ignored for elasticsearch.
+	// +optional
+	DatabaseName string `json:"databaseName,omitempty"`
+
+	// AWS enables AWS IAM (SigV4) request signing against Amazon OpenSearch Service.
+	// The credentials are resolved by the AWS SDK, e.g. from IRSA. It is only supported for opensearch,
+	// userName and password are optional when it is set.
+	// +optional
+	AWS *AWSAuthentication `json:"aws,omitempty"`
+
+	// TLS configures the certificates used to connect to the database.
+	// +optional
+	TLS *DatabaseTLS `json:"tls,omitempty"`
+}
+
+// DatabaseTLS references the certificates used for TLS connections to the database.
+type DatabaseTLS struct {
+	// CA is the PEM encoded certificate authority the database certificate is verified against.
+	// +optional
+	CA *CertificateSource `json:"ca,omitempty"`
+
+	// ClientCertificate references a kubernetes.io/tls Secret holding the PEM encoded client
+	// certificate (tls.crt) and key (tls.key). It is not supported for postgresql.
+	// +optional
+	ClientCertificate *corev1.LocalObjectReference `json:"clientCertificate,omitempty"`
+}
+
+// CertificateSource selects a PEM encoded certificate from either a Secret or a ConfigMap.
+type CertificateSource struct {
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
 type DatabaseType string
 
 const ElasticsearchDatabaseType DatabaseType = "elasticsearch"
+const OpensearchDatabaseType DatabaseType = "opensearch"
 const PostgresqlDatabaseType DatabaseType = "postgresql"
 
+// AWSAuthentication configures the AWS IAM authentication of the OpenSearch clients.
+type AWSAuthentication struct {
+	// Region is the AWS region of the OpenSearch domain.
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// ServiceName is the signing name of the service, es for OpenSearch Service domains
+	// and aoss for OpenSearch Serverless.
+	// +kubebuilder:validation:Enum=es;aoss
+	// +kubebuilder:default=es
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// RoleARN is the IAM role assumed through IRSA. It is set as eks.amazonaws.com/role-arn
+	// annotation on the service account of the cluster.
+	// +optional
+	RoleARN string `json:"roleArn,omitempty"`
+}
+
+// Condition types reported in OrchestrationClusterStatus.Conditions.
+const (
+	// ConditionReady is True when all brokers are part of the topology, all partitions
+	// are fully replicated and no change is in flight.
+	ConditionReady = "Ready"
+	// ConditionProgressing is True while the cluster converges, e.g. while a topology
+	// change is pending or the StatefulSet is rolling out.
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is True when brokers are missing from the topology or
+	// partitions have fewer active replicas than the replication factor.
+	ConditionDegraded = "Degraded"
+	// ConditionUpgradeBlocked is True when a version upgrade cannot proceed, either because the
+	// upgrade path is not supported or because an upgraded broker did not rejoin the cluster.
+	ConditionUpgradeBlocked = "UpgradeBlocked"
+	// ConditionReconcileError is True when the last reconciliation failed. Its message names the
+	// resource or step that failed.
+	ConditionReconcileError = "ReconcileError"
+)
+
+// Condition reasons reported in OrchestrationClusterStatus.Conditions.
+const (
+	ReasonClusterReady           = "ClusterReady"
+	ReasonBrokersNotReady        = "BrokersNotReady"
+	ReasonTopologyNotInitialized = "TopologyNotInitialized"
+	ReasonTopologyUnavailable    = "TopologyUnavailable"
+	ReasonTopologyChangePending  = "TopologyChangePending"
+	ReasonBrokersTransitioning   = "BrokersTransitioning"
+	ReasonRolloutInProgress      = "RolloutInProgress"
+	ReasonStable                 = "Stable"
+	ReasonBrokersMissing         = "BrokersMissing"
+	ReasonPartitionsUnhealthy    = "PartitionsUnhealthy"
+	ReasonHealthy                = "Healthy"
+	ReasonScalingUp              = "ScalingUp"
+	ReasonScalingDown            = "ScalingDown"
+	ReasonUpgrading              = "Upgrading"
+	ReasonUpgradePathValid       = "UpgradePathValid"
+	ReasonInvalidUpgradePath     = "InvalidUpgradePath"
+	ReasonUpgradeTargetChanged   = "UpgradeTargetChanged"
+	ReasonBrokerNotRejoined      = "BrokerNotRejoined"
+	ReasonVolumesExpanding       = "VolumesExpanding"
+	ReasonReconciled             = "Reconciled"
+	ReasonInvalidSpec            = "InvalidSpec"
+	ReasonApplyFailed            = "ApplyFailed"
+	ReasonPruneFailed            = "PruneFailed"
+	ReasonScalingFailed          = "ScalingFailed"
+	ReasonUpgradeFailed          = "UpgradeFailed"
+	ReasonStorageFailed          = "StorageFailed"
+)
+
 // OrchestrationClusterStatus defines the observed state of OrchestrationCluster.
 type OrchestrationClusterStatus struct {
 	// +patchMergeKey=type
@@ -71,6 +710,191 @@ type OrchestrationClusterStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"` //nolint:lll
+
+	// ObservedClusterSize is the number of brokers that are part of the cluster topology.
+	// +optional
+	ObservedClusterSize int32 `json:"observedClusterSize,omitempty"`
+
+	// TopologyVersion is the version of the last observed cluster topology.
+	// +optional
+	TopologyVersion int64 `json:"topologyVersion,omitempty"`
+
+	// PendingChanges is the number of topology change operations that are not completed yet.
+	// +optional
+	PendingChanges int32 `json:"pendingChanges,omitempty"`
+
+	// UnhealthyPartitions is the number of partitions with fewer active replicas than the
+	// replication factor, without a leader or with a replica the gateway reports as unhealthy.
+	// +optional
+	UnhealthyPartitions int32 `json:"unhealthyPartitions"`
+
+	// Brokers lists the brokers of the cluster topology with the partitions they replicate.
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Brokers []BrokerStatus `json:"brokers,omitempty"`
+
+	// Partitions summarizes leadership and health per partition.
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Partitions []PartitionStatus `json:"partitions,omitempty"`
+
+	// BootstrapClusterSize is the number of brokers the cluster was created with. It configures
+	// the initial contact points of the brokers, so changing spec.clusterSize does not roll the
+	// brokers; the topology is changed by the scaling operation instead.
+	// +optional
+	BootstrapClusterSize int32 `json:"bootstrapClusterSize,omitempty"`
+
+	// Scaling tracks a broker scaling operation that is in progress.
+	// It is persisted so that the operation can be resumed after an operator restart.
+	// +optional
+	Scaling *ScalingStatus `json:"scaling,omitempty"`
+
+	// Version is the version all brokers are running. It differs from spec.version
+	// until an upgrade is completed.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Upgrade tracks a version upgrade that is in progress.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// Volumes reports the size and the expansion of the data volume of each broker.
+	// +optional
+	// +listType=map
+	// +listMapKey=broker
+	Volumes []VolumeStatus `json:"volumes,omitempty"`
+}
+
+// VolumeExpansionPhase is the state of the data volume of a broker towards the requested size.
+type VolumeExpansionPhase string
+
+const (
+	// VolumeExpansionPending waits for the claim to be resized, e.g. because it is not bound yet
+	// or the resize request was rejected.
+	VolumeExpansionPending VolumeExpansionPhase = "Pending"
+	// VolumeExpansionResizing waits for the storage provider to resize the volume.
+	VolumeExpansionResizing VolumeExpansionPhase = "Resizing"
+	// VolumeExpansionFileSystemResizePending waits for the file system to be resized on the node.
+	VolumeExpansionFileSystemResizePending VolumeExpansionPhase = "FileSystemResizePending"
+	// VolumeExpansionCompleted is reported once the volume has the requested size.
+	VolumeExpansionCompleted VolumeExpansionPhase = "Completed"
+)
+
+// VolumeStatus is the observed state of the data volume of a broker.
+type VolumeStatus struct {
+	// Broker is the ordinal of the broker the volume belongs to.
+	Broker int32 `json:"broker"`
+	// ClaimName is the name of the persistent volume claim.
+	ClaimName string `json:"claimName"`
+	// Capacity is the actual size of the volume.
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Expansion is the state of the volume towards the requested size.
+	// +kubebuilder:validation:Enum=Pending;Resizing;FileSystemResizePending;Completed
+	Expansion VolumeExpansionPhase `json:"expansion"`
+	// Message describes why the expansion is not completed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradeStatus is the state of a rolling version upgrade. Brokers are upgraded one at a time,
+// starting with the highest ordinal, by lowering the partition of the StatefulSet rolling update.
+type UpgradeStatus struct {
+	// FromVersion is the version before the upgrade.
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is the version after the upgrade.
+	ToVersion string `json:"toVersion"`
+	// Partition is the ordinal from which on brokers run the new version.
+	Partition int32 `json:"partition"`
+	// BrokerUpdatedAt is the time the broker at Partition started its upgrade.
+	BrokerUpdatedAt metav1.Time `json:"brokerUpdatedAt"`
+	// StartedAt is the time the upgrade started.
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
+// ScalingPhase is a step of the broker scaling state machine.
+type ScalingPhase string
+
+const (
+	// ScalingPhaseScalingStatefulSet waits for the StatefulSet to run the new brokers before
+	// they are added to the cluster topology.
+	ScalingPhaseScalingStatefulSet ScalingPhase = "ScalingStatefulSet"
+	// ScalingPhaseRequestingChange requests the topology change through the management API.
+	ScalingPhaseRequestingChange ScalingPhase = "RequestingChange"
+	// ScalingPhaseAwaitingChange waits for the requested topology change to complete.
+	ScalingPhaseAwaitingChange ScalingPhase = "AwaitingChange"
+	// ScalingPhaseShrinkingStatefulSet removes the brokers that left the cluster topology.
+	ScalingPhaseShrinkingStatefulSet ScalingPhase = "ShrinkingStatefulSet"
+)
+
+// ScalingStatus is the state of a broker scaling operation.
+type ScalingStatus struct {
+	// Phase of the scaling operation.
+	// +kubebuilder:validation:Enum=ScalingStatefulSet;RequestingChange;AwaitingChange;ShrinkingStatefulSet
+	Phase ScalingPhase `json:"phase"`
+	// FromClusterSize is the number of brokers before scaling.
+	FromClusterSize int32 `json:"fromClusterSize"`
+	// ToClusterSize is the number of brokers after scaling.
+	ToClusterSize int32 `json:"toClusterSize"`
+	// ChangeID is the id of the topology change requested through the management API.
+	// +optional
+	ChangeID int64 `json:"changeId,omitempty"`
+	// Message describes the last observation of the scaling operation, e.g. a failed topology change.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartedAt is the time the scaling operation started.
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
+// PartitionRole is the role of a broker for a partition.
+type PartitionRole string
+
+const (
+	PartitionRoleLeader   PartitionRole = "leader"
+	PartitionRoleFollower PartitionRole = "follower"
+	PartitionRoleInactive PartitionRole = "inactive"
+)
+
+// BrokerStatus is the observed state of a single broker.
+type BrokerStatus struct {
+	ID int32 `json:"id"`
+	// State of the broker in the cluster topology, e.g. ACTIVE, JOINING or LEAVING.
+	State string `json:"state"`
+	// Partitions replicated by this broker.
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Partitions []BrokerPartitionStatus `json:"partitions,omitempty"`
+}
+
+// BrokerPartitionStatus is the observed state of a partition replica on a broker.
+type BrokerPartitionStatus struct {
+	ID int32 `json:"id"`
+	// Role of the broker for this partition as reported by the gateway. It is not set while the
+	// REST API of the cluster is unavailable.
+	// +optional
+	Role PartitionRole `json:"role,omitempty"`
+	// Health of the replica as reported by the gateway, e.g. healthy, unhealthy or dead.
+	// +optional
+	Health string `json:"health,omitempty"`
+	// State of the replica, e.g. ACTIVE or JOINING.
+	State string `json:"state"`
+}
+
+// PartitionStatus is the observed state of a partition across all brokers.
+type PartitionStatus struct {
+	ID int32 `json:"id"`
+	// Leader is the id of the broker leading this partition as reported by the gateway.
+	// +optional
+	Leader *int32 `json:"leader,omitempty"`
+	// Replicas is the number of active replicas of this partition.
+	Replicas int32 `json:"replicas"`
+	// Healthy is true if the partition has at least as many active replicas as the replication
+	// factor, a leader and no replica the gateway reports as unhealthy. Only the replicas are
+	// checked while the REST API of the cluster is unavailable.
+	Healthy bool `json:"healthy"`
 }
 
 // +kubebuilder:object:root=true
@@ -82,6 +906,17 @@ type OrchestrationClusterStatus struct {
 // +kubebuilder:printcolumn:name="Replication",type="integer",JSONPath=".spec.replicationFactor"
 // +kubebuilder:printcolumn:name="Database",type="string",JSONPath=".spec.database.type"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Progressing",type="string",JSONPath=".status.conditions[?(@.type=='Progressing')].status"
+// +kubebuilder:printcolumn:name="Degraded",type="string",JSONPath=".status.conditions[?(@.type=='Degraded')].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",priority=1
+// +kubebuilder:printcolumn:name="Brokers",type="integer",JSONPath=".status.observedClusterSize"
+// +kubebuilder:printcolumn:name="Unhealthy Partitions",type="integer",JSONPath=".status.unhealthyPartitions"
+// +kubebuilder:printcolumn:name="Leaders",type="string",JSONPath=".status.partitions[*].leader",priority=1
+// +kubebuilder:printcolumn:name="Topology",type="integer",JSONPath=".status.topologyVersion",priority=1
+// +kubebuilder:printcolumn:name="Pending Changes",type="integer",JSONPath=".status.pendingChanges",priority=1
+// +kubebuilder:printcolumn:name="Running Version",type="string",JSONPath=".status.version",priority=1
+// +kubebuilder:printcolumn:name="Upgrade Blocked",type="string",JSONPath=".status.conditions[?(@.type=='UpgradeBlocked')].status",priority=1
+// +kubebuilder:printcolumn:name="Scaling",type="string",JSONPath=".status.scaling.phase",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // OrchestrationCluster is the Schema for the orchestrationclusters API.
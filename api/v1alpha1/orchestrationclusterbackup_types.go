@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrchestrationClusterBackupSpec defines the desired state of OrchestrationClusterBackup.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type OrchestrationClusterBackupSpec struct {
+	// ClusterName is the name of the OrchestrationCluster in the same namespace to back up.
+	// The cluster must have a backup store configured.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// BackupID identifies the backup in the backup store. It must be greater than the ids of all
+	// previous backups of the cluster. Defaults to the creation time of the resource in unix seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BackupID *int64 `json:"backupID,omitempty"`
+}
+
+// BackupPhase is the step a backup is in.
+type BackupPhase string
+
+const (
+	// BackupPhasePending is set until the backup is started.
+	BackupPhasePending BackupPhase = "Pending"
+	// BackupPhaseBackingUpSecondaryStorage waits for the snapshots of the secondary storage indices.
+	BackupPhaseBackingUpSecondaryStorage BackupPhase = "BackingUpSecondaryStorage"
+	// BackupPhaseBackingUpBrokers waits for the broker backup while exporting is paused.
+	BackupPhaseBackingUpBrokers BackupPhase = "BackingUpBrokers"
+	// BackupPhaseCompleted is set once all parts of the backup are completed.
+	BackupPhaseCompleted BackupPhase = "Completed"
+	// BackupPhaseFailed is set if a part of the backup failed. Failed backups are not retried,
+	// create a new backup with a greater id instead.
+	BackupPhaseFailed BackupPhase = "Failed"
+)
+
+// OrchestrationClusterBackupStatus defines the observed state of OrchestrationClusterBackup.
+type OrchestrationClusterBackupStatus struct {
+	// BackupID is the id the backup is taken with.
+	// +optional
+	BackupID int64 `json:"backupID,omitempty"`
+
+	// +kubebuilder:validation:Enum=Pending;BackingUpSecondaryStorage;BackingUpBrokers;Completed;Failed
+	// +optional
+	Phase BackupPhase `json:"phase,omitempty"`
+
+	// SecondaryStorage is the state of the backup of the Elasticsearch or OpenSearch indices.
+	// +optional
+	SecondaryStorage *ComponentBackupStatus `json:"secondaryStorage,omitempty"`
+
+	// Records is the state of the snapshot of the records exported to Elasticsearch or OpenSearch.
+	// +optional
+	Records *ComponentBackupStatus `json:"records,omitempty"`
+
+	// Brokers is the state of the backup of the broker partitions.
+	// +optional
+	Brokers *ComponentBackupStatus `json:"brokers,omitempty"`
+
+	// Message describes what the backup is waiting for or why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ComponentBackupStatus is the state of one part of a backup.
+type ComponentBackupStatus struct {
+	// State as reported by the backup API, e.g. IN_PROGRESS, COMPLETED or FAILED.
+	State string `json:"state"`
+
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// Snapshots are the names of the snapshots in the snapshot repository of the database.
+	// +optional
+	Snapshots []string `json:"snapshots,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ocb
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Backup ID",type="integer",JSONPath=".status.backupID"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OrchestrationClusterBackup is the Schema for the orchestrationclusterbackups API.
+// It takes a point in time backup of the brokers and the secondary storage of a cluster.
+type OrchestrationClusterBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrchestrationClusterBackupSpec   `json:"spec,omitempty"`
+	Status OrchestrationClusterBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrchestrationClusterBackupList contains a list of OrchestrationClusterBackup.
+type OrchestrationClusterBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrchestrationClusterBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrchestrationClusterBackup{}, &OrchestrationClusterBackupList{})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition reasons reported in the Ready condition of OrchestrationClusterBackupSchedules.
+const (
+	ReasonScheduled       = "Scheduled"
+	ReasonInvalidSchedule = "InvalidSchedule"
+)
+
+// OrchestrationClusterBackupScheduleSpec defines the desired state of OrchestrationClusterBackupSchedule.
+type OrchestrationClusterBackupScheduleSpec struct {
+	// ClusterName is the name of the OrchestrationCluster in the same namespace to back up.
+	// The cluster must have a backup store configured.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// Schedule in cron format, e.g. "0 2 * * *", or one of @hourly, @daily, @weekly, @monthly.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone the schedule is evaluated in, e.g. "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// Suspend stops creating new backups. Existing backups are still pruned.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Retention selects the completed backups to keep. The other backups are deleted from the
+	// backup store together with their OrchestrationClusterBackup resources.
+	// +kubebuilder:default={}
+	// +optional
+	Retention BackupRetention `json:"retention,omitempty"`
+}
+
+// BackupRetention keeps the newest completed backup of each of the last days and weeks that have
+// a completed backup. A backup selected by both rules is kept once. The newest completed backup
+// is always kept.
+type BackupRetention struct {
+	// Daily is the number of days to keep the newest backup of.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=7
+	// +optional
+	Daily int32 `json:"daily"`
+
+	// Weekly is the number of weeks to keep the newest backup of. Weeks start on Monday.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=4
+	// +optional
+	Weekly int32 `json:"weekly"`
+}
+
+// OrchestrationClusterBackupScheduleStatus defines the observed state of OrchestrationClusterBackupSchedule.
+type OrchestrationClusterBackupScheduleStatus struct {
+	// LastScheduleTime is the time the last backup was scheduled for.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the completion time of the newest completed backup.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// LastFailureTime is the completion time of the newest failed backup.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// LastFailureMessage describes why the newest failed backup failed.
+	// +optional
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+
+	// Active is the name of the backup in progress.
+	// +optional
+	Active string `json:"active,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ocbs
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Last Success",type="date",JSONPath=".status.lastSuccessfulTime"
+// +kubebuilder:printcolumn:name="Last Failure",type="date",JSONPath=".status.lastFailureTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OrchestrationClusterBackupSchedule is the Schema for the orchestrationclusterbackupschedules API.
+// It creates OrchestrationClusterBackups of a cluster on a cron schedule and prunes old backups.
+type OrchestrationClusterBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrchestrationClusterBackupScheduleSpec   `json:"spec,omitempty"`
+	Status OrchestrationClusterBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrchestrationClusterBackupScheduleList contains a list of OrchestrationClusterBackupSchedule.
+type OrchestrationClusterBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrchestrationClusterBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrchestrationClusterBackupSchedule{}, &OrchestrationClusterBackupScheduleList{})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrchestrationClusterRestoreSpec defines the desired state of OrchestrationClusterRestore.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type OrchestrationClusterRestoreSpec struct {
+	// BackupID of the backup to restore.
+	// +kubebuilder:validation:Minimum=1
+	BackupID int64 `json:"backupID"`
+
+	// Cluster is the OrchestrationCluster brought up from the backup. It must not exist yet.
+	Cluster ClusterTemplate `json:"cluster"`
+}
+
+// ClusterTemplate describes the OrchestrationCluster created by a restore.
+// +kubebuilder:validation:XValidation:rule="has(self.spec.backup)",message="spec.backup must be set to restore from it"
+type ClusterTemplate struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Spec of the cluster. The partition count and the backup store must match the backed up cluster.
+	Spec OrchestrationClusterSpec `json:"spec"`
+}
+
+// RestorePhase is the step a restore is in.
+type RestorePhase string
+
+const (
+	// RestorePhasePending is set until the restore is started.
+	RestorePhasePending RestorePhase = "Pending"
+	// RestorePhaseRestoringSecondaryStorage restores the snapshots of the secondary storage indices.
+	RestorePhaseRestoringSecondaryStorage RestorePhase = "RestoringSecondaryStorage"
+	// RestorePhaseRestoringBrokers waits for the brokers to restore their data and become ready.
+	RestorePhaseRestoringBrokers RestorePhase = "RestoringBrokers"
+	// RestorePhaseCompleted is set once the restored cluster is ready.
+	RestorePhaseCompleted RestorePhase = "Completed"
+	// RestorePhaseFailed is set if the restore cannot be completed.
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// OrchestrationClusterRestoreStatus defines the observed state of OrchestrationClusterRestore.
+type OrchestrationClusterRestoreStatus struct {
+	// +kubebuilder:validation:Enum=Pending;RestoringSecondaryStorage;RestoringBrokers;Completed;Failed
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// RestoredSnapshots are the snapshots of the secondary storage that were restored.
+	// +optional
+	RestoredSnapshots []string `json:"restoredSnapshots,omitempty"`
+
+	// Message describes what the restore is waiting for or why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ocr
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Backup ID",type="integer",JSONPath=".spec.backupID"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OrchestrationClusterRestore is the Schema for the orchestrationclusterrestores API.
+// It brings up a new cluster from a backup taken with an OrchestrationClusterBackup.
+type OrchestrationClusterRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrchestrationClusterRestoreSpec   `json:"spec,omitempty"`
+	Status OrchestrationClusterRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrchestrationClusterRestoreList contains a list of OrchestrationClusterRestore.
+type OrchestrationClusterRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrchestrationClusterRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrchestrationClusterRestore{}, &OrchestrationClusterRestoreList{})
+}
@@ -18,9 +18,13 @@ package main
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -33,12 +37,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
 	"github.com/camunda/camunda-operator/internal/controller"
+	"github.com/camunda/camunda-operator/internal/metrics"
+	webhookv1alpha1 "github.com/camunda/camunda-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -63,6 +70,11 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var resyncInterval time.Duration
+	var clusterDomain string
+	var managementTimeout time.Duration
+	var managementTLS, restTLS clientTLSFlags
+	var managementUsername, managementPasswordFile string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +93,24 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"The interval in which clusters are reconciled even if nothing changed. Use 0 to disable the resync.")
+	flag.StringVar(&clusterDomain, "cluster-domain", controller.DefaultClusterDomain,
+		"The DNS domain of the Kubernetes cluster the Services of the clusters are resolved in.")
+	flag.DurationVar(&managementTimeout, "management-timeout", controller.DefaultManagementTimeout,
+		"The timeout of requests to the management API of the clusters.")
+	flag.BoolVar(&managementTLS.enabled, "management-tls", false, "If set, the management API is called with HTTPS.")
+	flag.StringVar(&managementTLS.caFile, "management-ca-file", "",
+		"The file with the CA certificates the management API certificates are verified with. "+
+			"The system CAs are used if empty.")
+	flag.BoolVar(&restTLS.enabled, "rest-tls", false, "If set, the REST API of the clusters is called with HTTPS.")
+	flag.StringVar(&restTLS.caFile, "rest-ca-file", "",
+		"The file with the CA certificates the REST API certificates are verified with. "+
+			"The system CAs are used if empty.")
+	flag.StringVar(&managementUsername, "management-username", "",
+		"The username to authenticate against the management API with basic authentication.")
+	flag.StringVar(&managementPasswordFile, "management-password-file", "",
+		"The file with the password to authenticate against the management API.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -202,15 +232,96 @@ func main() {
 		os.Exit(1)
 	}
 
+	healthCheckerOptions, err := managementOptions(
+		clusterDomain, managementTimeout, managementTLS, restTLS, managementUsername, managementPasswordFile)
+	if err != nil {
+		setupLog.Error(err, "unable to configure the management API client")
+		os.Exit(1)
+	}
+	healthChecker := controller.NewClusterHealthChecker(mgr.GetClient(), healthCheckerOptions)
 	if err := (&controller.OrchestrationClusterReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorderFor("orchestrationcluster-controller"),
+		HealthChecker:  healthChecker,
+		APIReader:      mgr.GetAPIReader(),
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OrchestrationCluster")
 		os.Exit(1)
 	}
+	if err := (&controller.OrchestrationClusterBackupReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		APIReader:     mgr.GetAPIReader(),
+		HealthChecker: healthChecker,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OrchestrationClusterBackup")
+		os.Exit(1)
+	}
+	if err := (&controller.OrchestrationClusterBackupScheduleReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		Recorder:      mgr.GetEventRecorderFor("orchestrationclusterbackupschedule-controller"),
+		APIReader:     mgr.GetAPIReader(),
+		HealthChecker: healthChecker,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OrchestrationClusterBackupSchedule")
+		os.Exit(1)
+	}
+	if err := (&controller.OrchestrationClusterRestoreReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OrchestrationClusterRestore")
+		os.Exit(1)
+	}
+	if err := (&controller.CamundaUserReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		APIReader:     mgr.GetAPIReader(),
+		HealthChecker: healthChecker,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CamundaUser")
+		os.Exit(1)
+	}
+	if err := (&controller.CamundaGroupReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		APIReader:     mgr.GetAPIReader(),
+		HealthChecker: healthChecker,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CamundaGroup")
+		os.Exit(1)
+	}
+	if err := (&controller.CamundaAuthorizationReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		APIReader:     mgr.GetAPIReader(),
+		HealthChecker: healthChecker,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CamundaAuthorization")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupOrchestrationClusterWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OrchestrationCluster")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupOrchestrationClusterBackupScheduleWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OrchestrationClusterBackupSchedule")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if err := ctrlmetrics.Registry.Register(metrics.NewClusterCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register cluster metrics")
+		os.Exit(1)
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -242,3 +353,58 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// clientTLSFlags enable HTTPS for an API of the clusters.
+type clientTLSFlags struct {
+	enabled bool
+	caFile  string
+}
+
+// config returns the TLS configuration the API is called with, or nil if HTTPS is not enabled.
+func (f clientTLSFlags) config() (*tls.Config, error) {
+	if !f.enabled {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if f.caFile != "" {
+		caCerts, err := os.ReadFile(f.caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCerts) {
+			return nil, fmt.Errorf("no certificates found in %s", f.caFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// managementOptions configures how the operator reaches the management and REST API of the clusters.
+func managementOptions(
+	clusterDomain string,
+	timeout time.Duration,
+	managementTLS, restTLS clientTLSFlags,
+	username, passwordFile string,
+) (controller.HealthCheckerOptions, error) {
+	opts := controller.HealthCheckerOptions{
+		ClusterDomain: clusterDomain,
+		Timeout:       timeout,
+		Username:      username,
+	}
+	var err error
+	if opts.TLSConfig, err = managementTLS.config(); err != nil {
+		return opts, err
+	}
+	if opts.RESTTLSConfig, err = restTLS.config(); err != nil {
+		return opts, err
+	}
+	if passwordFile != "" {
+		password, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return opts, err
+		}
+		opts.Password = strings.TrimSpace(string(password))
+	}
+	return opts, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/identity"
+)
+
+// CamundaAuthorizationReconciler reconciles a CamundaAuthorization object
+type CamundaAuthorizationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the credentials of the cluster without caching Secrets.
+	APIReader client.Reader
+	// HealthChecker reaches the REST API of the cluster.
+	HealthChecker ClusterHealthChecker
+}
+
+// nolint:lll
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundaauthorizations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundaauthorizations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundaauthorizations/finalizers,verbs=update
+
+// Reconcile syncs the authorization into the REST API of its cluster and deletes it there once it
+// is deleted. The key of the authorization in the cluster is kept in the status.
+func (r *CamundaAuthorizationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	authorization := new(corev1alpha1.CamundaAuthorization)
+	if err := r.Get(ctx, req.NamespacedName, authorization); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := logf.FromContext(ctx, "cluster", authorization.Spec.ClusterName, "owner", authorization.Spec.OwnerID)
+
+	osc, err := identityCluster(ctx, r.Client, authorization.Namespace, authorization.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !authorization.DeletionTimestamp.IsZero() {
+		deleteFromCluster := func(c *identity.Client) error {
+			if authorization.Status.AuthorizationKey == "" {
+				return nil
+			}
+			return c.DeleteAuthorization(ctx, authorization.Status.AuthorizationKey)
+		}
+		return ctrl.Result{}, finalizeIdentity(ctx, r.Client, r.HealthChecker, r.APIReader, authorization, osc,
+			deleteFromCluster)
+	}
+
+	status := authorization.Status.DeepCopy()
+	if osc == nil {
+		setClusterNotFoundCondition(&status.Conditions, authorization.Generation, authorization.Spec.ClusterName)
+		return ctrl.Result{RequeueAfter: identityResyncInterval}, r.updateStatus(ctx, authorization, status)
+	}
+	if err := ensureIdentityFinalizer(ctx, r.Client, authorization); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.sync(ctx, osc, authorization, status)
+	setSyncedCondition(&status.Conditions, authorization.Generation, err)
+	if err == nil {
+		status.ObservedGeneration = authorization.Generation
+	}
+	if err := r.updateStatus(ctx, authorization, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		log.Error(err, "Failed to sync authorization")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: identityResyncInterval}, nil
+}
+
+func (r *CamundaAuthorizationReconciler) sync(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	authorization *corev1alpha1.CamundaAuthorization,
+	status *corev1alpha1.CamundaAuthorizationStatus,
+) error {
+	c, err := newIdentityClient(ctx, r.HealthChecker, r.APIReader, osc)
+	if err != nil {
+		return err
+	}
+	desired := identity.Authorization{
+		OwnerID:      authorization.Spec.OwnerID,
+		OwnerType:    authorization.Spec.OwnerType,
+		ResourceType: authorization.Spec.ResourceType,
+		ResourceID:   authorization.Spec.ResourceID,
+	}
+	for _, permission := range authorization.Spec.Permissions {
+		desired.PermissionTypes = append(desired.PermissionTypes, string(permission))
+	}
+	key, err := identity.SyncAuthorization(ctx, c, status.AuthorizationKey, desired)
+	if err != nil {
+		return err
+	}
+	status.AuthorizationKey = key
+	return nil
+}
+
+// updateStatus writes the status if it differs from the current status of the authorization.
+func (r *CamundaAuthorizationReconciler) updateStatus(
+	ctx context.Context,
+	authorization *corev1alpha1.CamundaAuthorization,
+	status *corev1alpha1.CamundaAuthorizationStatus,
+) error {
+	if equality.Semantic.DeepEqual(authorization.Status, *status) {
+		return nil
+	}
+	authorization.Status = *status
+	return r.Status().Update(ctx, authorization)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CamundaAuthorizationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.CamundaAuthorization{}).
+		Named("camundaauthorization").
+		Complete(r)
+}
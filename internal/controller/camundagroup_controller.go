@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/identity"
+)
+
+// CamundaGroupReconciler reconciles a CamundaGroup object
+type CamundaGroupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the credentials of the cluster without caching Secrets.
+	APIReader client.Reader
+	// HealthChecker reaches the REST API of the cluster.
+	HealthChecker ClusterHealthChecker
+}
+
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundagroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundagroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundagroups/finalizers,verbs=update
+
+// Reconcile syncs the group and its members into the REST API of its cluster and deletes the group
+// there once it is deleted.
+func (r *CamundaGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	group := new(corev1alpha1.CamundaGroup)
+	if err := r.Get(ctx, req.NamespacedName, group); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := logf.FromContext(ctx, "cluster", group.Spec.ClusterName, "groupID", group.Spec.GroupID)
+
+	osc, err := identityCluster(ctx, r.Client, group.Namespace, group.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !group.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, finalizeIdentity(ctx, r.Client, r.HealthChecker, r.APIReader, group, osc,
+			func(c *identity.Client) error {
+				return c.DeleteGroup(ctx, group.Spec.GroupID)
+			})
+	}
+
+	status := group.Status.DeepCopy()
+	if osc == nil {
+		setClusterNotFoundCondition(&status.Conditions, group.Generation, group.Spec.ClusterName)
+		return ctrl.Result{RequeueAfter: identityResyncInterval}, r.updateStatus(ctx, group, status)
+	}
+	if err := ensureIdentityFinalizer(ctx, r.Client, group); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.sync(ctx, osc, group)
+	setSyncedCondition(&status.Conditions, group.Generation, err)
+	if err == nil {
+		status.ObservedGeneration = group.Generation
+	}
+	if err := r.updateStatus(ctx, group, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		log.Error(err, "Failed to sync group")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: identityResyncInterval}, nil
+}
+
+func (r *CamundaGroupReconciler) sync(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	group *corev1alpha1.CamundaGroup,
+) error {
+	c, err := newIdentityClient(ctx, r.HealthChecker, r.APIReader, osc)
+	if err != nil {
+		return err
+	}
+	desired := identity.Group{
+		GroupID:     group.Spec.GroupID,
+		Name:        group.Spec.Name,
+		Description: group.Spec.Description,
+	}
+	return identity.SyncGroup(ctx, c, desired, group.Spec.Users)
+}
+
+// updateStatus writes the status if it differs from the current status of the group.
+func (r *CamundaGroupReconciler) updateStatus(
+	ctx context.Context,
+	group *corev1alpha1.CamundaGroup,
+	status *corev1alpha1.CamundaGroupStatus,
+) error {
+	if equality.Semantic.DeepEqual(group.Status, *status) {
+		return nil
+	}
+	group.Status = *status
+	return r.Status().Update(ctx, group)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CamundaGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.CamundaGroup{}).
+		Named("camundagroup").
+		Complete(r)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/identity"
+)
+
+// CamundaUserReconciler reconciles a CamundaUser object
+type CamundaUserReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the credentials of the cluster without caching Secrets.
+	APIReader client.Reader
+	// HealthChecker reaches the REST API of the cluster.
+	HealthChecker ClusterHealthChecker
+}
+
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundausers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundausers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.camunda.io,resources=camundausers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// Reconcile syncs the user into the REST API of its cluster and deletes it there once it is deleted.
+func (r *CamundaUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	user := new(corev1alpha1.CamundaUser)
+	if err := r.Get(ctx, req.NamespacedName, user); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := logf.FromContext(ctx, "cluster", user.Spec.ClusterName, "username", user.Spec.Username)
+
+	osc, err := identityCluster(ctx, r.Client, user.Namespace, user.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !user.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, finalizeIdentity(ctx, r.Client, r.HealthChecker, r.APIReader, user, osc,
+			func(c *identity.Client) error {
+				return c.DeleteUser(ctx, user.Spec.Username)
+			})
+	}
+
+	status := user.Status.DeepCopy()
+	if osc == nil {
+		setClusterNotFoundCondition(&status.Conditions, user.Generation, user.Spec.ClusterName)
+		return ctrl.Result{RequeueAfter: identityResyncInterval}, r.updateStatus(ctx, user, status)
+	}
+	if err := ensureIdentityFinalizer(ctx, r.Client, user); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.sync(ctx, osc, user, status)
+	setSyncedCondition(&status.Conditions, user.Generation, err)
+	if err == nil {
+		status.ObservedGeneration = user.Generation
+	}
+	if err := r.updateStatus(ctx, user, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		log.Error(err, "Failed to sync user")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: identityResyncInterval}, nil
+}
+
+// sync creates or updates the user. The password is set again when the password Secret changed.
+func (r *CamundaUserReconciler) sync(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	user *corev1alpha1.CamundaUser,
+	status *corev1alpha1.CamundaUserStatus,
+) error {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: user.Namespace, Name: user.Spec.PasswordSecret.Name}
+	if err := r.APIReader.Get(ctx, key, secret); err != nil {
+		return fmt.Errorf("failed to get password secret: %w", err)
+	}
+	password, ok := secret.Data[user.Spec.PasswordSecret.Key]
+	if !ok {
+		return fmt.Errorf("key %s not found in secret %s", user.Spec.PasswordSecret.Key, key.Name)
+	}
+
+	c, err := newIdentityClient(ctx, r.HealthChecker, r.APIReader, osc)
+	if err != nil {
+		return err
+	}
+	desired := identity.User{
+		Username: user.Spec.Username,
+		Name:     user.Spec.Name,
+		Email:    user.Spec.Email,
+		Password: string(password),
+	}
+	if err := identity.SyncUser(ctx, c, desired, status.PasswordSecretVersion != secret.ResourceVersion); err != nil {
+		return err
+	}
+	status.PasswordSecretVersion = secret.ResourceVersion
+	return nil
+}
+
+// updateStatus writes the status if it differs from the current status of the user.
+func (r *CamundaUserReconciler) updateStatus(
+	ctx context.Context,
+	user *corev1alpha1.CamundaUser,
+	status *corev1alpha1.CamundaUserStatus,
+) error {
+	if equality.Semantic.DeepEqual(user.Status, *status) {
+		return nil
+	}
+	user.Status = *status
+	return r.Status().Update(ctx, user)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CamundaUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.CamundaUser{}).
+		Named("camundauser").
+		Complete(r)
+}
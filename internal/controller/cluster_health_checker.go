@@ -0,0 +1,332 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/backup"
+	"github.com/camunda/camunda-operator/pkg/bundles/mycustom"
+	"github.com/camunda/camunda-operator/pkg/identity"
+	"github.com/camunda/camunda-operator/pkg/scaling"
+)
+
+// Defaults of the HealthCheckerOptions.
+const (
+	DefaultClusterDomain     = "cluster.local"
+	DefaultManagementTimeout = 10 * time.Second
+)
+
+// ClusterHealthChecker talks to the management API of the clusters. The reconciler reads the
+// topology of a cluster for its status and scales and upgrades it through the same API. Backups
+// and the identity resources reach the clusters through it as well, so every request is made with
+// the same cluster domain, TLS configuration and timeout.
+type ClusterHealthChecker interface {
+	// Topology returns the current topology of the cluster.
+	Topology(ctx context.Context, osc *corev1alpha1.OrchestrationCluster) (*management.TopologyResponse, error)
+	// ManagementAPI returns the cluster endpoints of the management API of the cluster.
+	ManagementAPI(ctx context.Context, osc *corev1alpha1.OrchestrationCluster) (scaling.Cluster, error)
+	// Actuator returns the backup and exporting endpoints of the management API of the cluster.
+	Actuator(ctx context.Context, osc *corev1alpha1.OrchestrationCluster) (backup.Actuator, error)
+	// RESTAPI returns the URL of the REST API of the cluster and the HTTP client to reach it with.
+	// The client does not authenticate, the REST API has its own authentication.
+	RESTAPI(ctx context.Context, osc *corev1alpha1.OrchestrationCluster) (*url.URL, *http.Client, error)
+	// TokenSource returns the source of the tokens the REST API of a cluster with OIDC is called
+	// with. The source is kept per cluster, so the issuer is only discovered again and a token only
+	// requested again once the client credentials changed or the token expired.
+	TokenSource(
+		ctx context.Context,
+		osc *corev1alpha1.OrchestrationCluster,
+		clientSecret string,
+	) (oauth2.TokenSource, error)
+	// Forget releases what is kept for a deleted cluster.
+	Forget(osc *corev1alpha1.OrchestrationCluster)
+}
+
+// HealthCheckerOptions configure how the management API of the clusters is reached.
+type HealthCheckerOptions struct {
+	// ClusterDomain is the DNS domain of the Kubernetes cluster. Defaults to DefaultClusterDomain.
+	ClusterDomain string
+	// Timeout bounds every request. Defaults to DefaultManagementTimeout.
+	Timeout time.Duration
+	// TLSConfig enables HTTPS on the management port if set.
+	TLSConfig *tls.Config
+	// RESTTLSConfig enables HTTPS on the REST API if set. The REST API is served by the gateway and
+	// configured apart from the management port, so it is not switched to HTTPS with TLSConfig.
+	RESTTLSConfig *tls.Config
+	// Username and Password authenticate the requests to the management API with basic
+	// authentication if set.
+	Username string
+	Password string
+}
+
+// NewClusterHealthChecker returns a ClusterHealthChecker that finds the management API through the
+// Services of the clusters and keeps one client per cluster.
+func NewClusterHealthChecker(cli client.Client, opts HealthCheckerOptions) ClusterHealthChecker {
+	if opts.ClusterDomain == "" {
+		opts.ClusterDomain = DefaultClusterDomain
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultManagementTimeout
+	}
+
+	transport := tlsTransport(opts.TLSConfig)
+	if opts.Username != "" {
+		transport = &basicAuthTransport{next: transport, username: opts.Username, password: opts.Password}
+	}
+
+	return &managementHealthChecker{
+		client:       cli,
+		opts:         opts,
+		transport:    transport,
+		httpClient:   &http.Client{Transport: transport, Timeout: opts.Timeout},
+		restClient:   &http.Client{Transport: tlsTransport(opts.RESTTLSConfig), Timeout: opts.Timeout},
+		clients:      map[types.NamespacedName]*cachedManagementClient{},
+		tokenSources: map[types.NamespacedName]*cachedTokenSource{},
+	}
+}
+
+// tlsTransport returns a transport that verifies the servers with the TLS configuration, or the
+// default transport if it is nil.
+func tlsTransport(config *tls.Config) http.RoundTripper {
+	if config == nil {
+		return http.DefaultTransport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
+
+type managementHealthChecker struct {
+	client     client.Client
+	opts       HealthCheckerOptions
+	transport  http.RoundTripper
+	httpClient *http.Client
+	restClient *http.Client
+
+	mu           sync.Mutex
+	clients      map[types.NamespacedName]*cachedManagementClient
+	tokenSources map[types.NamespacedName]*cachedTokenSource
+}
+
+// cachedManagementClient holds the clients of a cluster together with the URL they were created for.
+type cachedManagementClient struct {
+	baseURL  url.URL
+	client   *management.Client
+	actuator *actuator.Client
+}
+
+func (c *managementHealthChecker) Topology(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*management.TopologyResponse, error) {
+	cluster, err := c.ManagementAPI(ctx, osc)
+	if err != nil {
+		return nil, err
+	}
+	return cluster.Topology(ctx)
+}
+
+func (c *managementHealthChecker) ManagementAPI(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (scaling.Cluster, error) {
+	cached, err := c.cachedClient(ctx, osc)
+	if err != nil {
+		return nil, err
+	}
+	return timeoutCluster{Cluster: cached.client.Cluster, timeout: c.opts.Timeout}, nil
+}
+
+func (c *managementHealthChecker) Actuator(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (backup.Actuator, error) {
+	cached, err := c.cachedClient(ctx, osc)
+	if err != nil {
+		return nil, err
+	}
+	return cached.actuator, nil
+}
+
+func (c *managementHealthChecker) RESTAPI(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*url.URL, *http.Client, error) {
+	svc := &corev1.Service{}
+	key := client.ObjectKey{Namespace: osc.Namespace, Name: mycustom.GatewayServiceName(*osc)}
+	if err := c.client.Get(ctx, key, svc); err != nil {
+		return nil, nil, fmt.Errorf("failed to get service %s: %w", key.Name, err)
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Name == "http" {
+			return c.serviceURL(svc, port.Port, c.opts.RESTTLSConfig), c.restClient, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no http port found on service %s", key.Name)
+}
+
+// cachedTokenSource is the token source of a cluster together with the client credentials it was
+// created for.
+type cachedTokenSource struct {
+	credentials tokenCredentials
+	source      oauth2.TokenSource
+}
+
+// tokenCredentials are the settings a token source is created from.
+type tokenCredentials struct {
+	issuerURL, clientID, clientSecret, audiences string
+}
+
+func (c *managementHealthChecker) TokenSource(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	clientSecret string,
+) (oauth2.TokenSource, error) {
+	if osc.Spec.Authentication == nil || osc.Spec.Authentication.OIDC == nil {
+		return nil, fmt.Errorf("cluster %s does not authenticate with OIDC", osc.Name)
+	}
+	oidc := osc.Spec.Authentication.OIDC
+	credentials := tokenCredentials{
+		issuerURL:    oidc.IssuerURL,
+		clientID:     oidc.ClientID,
+		clientSecret: clientSecret,
+		audiences:    strings.Join(oidc.Audiences, " "),
+	}
+
+	key := client.ObjectKeyFromObject(osc)
+	c.mu.Lock()
+	cached, ok := c.tokenSources[key]
+	c.mu.Unlock()
+	if ok && cached.credentials == credentials {
+		return cached.source, nil
+	}
+
+	discoveryCtx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+	tokenURL, err := identity.DiscoverTokenURL(discoveryCtx, c.restClient, oidc.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+	config := clientcredentials.Config{
+		ClientID:     oidc.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+	}
+	if len(oidc.Audiences) > 0 {
+		config.EndpointParams = url.Values{"audience": oidc.Audiences}
+	}
+	// The source outlives the reconciliation, so tokens are requested with a context of their own
+	// that carries the client with the timeout and TLS configuration.
+	source := config.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, c.restClient))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokenSources[key] = &cachedTokenSource{credentials: credentials, source: source}
+	return source, nil
+}
+
+func (c *managementHealthChecker) Forget(osc *corev1alpha1.OrchestrationCluster) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clients, client.ObjectKeyFromObject(osc))
+	delete(c.tokenSources, client.ObjectKeyFromObject(osc))
+}
+
+// cachedClient returns the clients of the management API of the cluster. They are created again if
+// the Service of the cluster changed.
+func (c *managementHealthChecker) cachedClient(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*cachedManagementClient, error) {
+	svc, err := lookupService(ctx, c.client, osc, actuator.Port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup service for osc %s: %w", osc.Name, err)
+	}
+	baseURL := c.serviceURL(svc, actuator.Port, c.opts.TLSConfig)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := client.ObjectKeyFromObject(osc)
+	if cached, ok := c.clients[key]; ok && cached.baseURL == *baseURL {
+		return cached, nil
+	}
+	managementClient, err := management.NewClient(
+		management.WithBaseURL(*baseURL),
+		management.WithTransport(c.transport),
+	)
+	if err != nil {
+		return nil, err
+	}
+	cached := &cachedManagementClient{
+		baseURL:  *baseURL,
+		client:   managementClient,
+		actuator: actuator.NewClient(*baseURL, actuator.WithHTTPClient(c.httpClient)),
+	}
+	c.clients[key] = cached
+	return cached, nil
+}
+
+// serviceURL returns the URL of a port of the Service within the cluster domain. The port is called
+// with HTTPS if it has a TLS configuration.
+func (c *managementHealthChecker) serviceURL(svc *corev1.Service, port int32, tlsConfig *tls.Config) *url.URL {
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	return &url.URL{
+		Scheme: scheme,
+		Host:   fmt.Sprintf("%s.%s.svc.%s:%d", svc.Name, svc.Namespace, c.opts.ClusterDomain, port),
+	}
+}
+
+// timeoutCluster bounds every call to the management API by the timeout.
+type timeoutCluster struct {
+	management.Cluster
+	timeout time.Duration
+}
+
+func (c timeoutCluster) Topology(ctx context.Context) (*management.TopologyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.Cluster.Topology(ctx)
+}
+
+func (c timeoutCluster) ScaleBrokers(
+	ctx context.Context,
+	brokerIds []management.BrokerId,
+	dryRun bool,
+	force bool,
+	replicationFactor *int32,
+) (*management.PlannedOperationsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.Cluster.ScaleBrokers(ctx, brokerIds, dryRun, force, replicationFactor)
+}
+
+// basicAuthTransport adds basic authentication to every request.
+type basicAuthTransport struct {
+	next     http.RoundTripper
+	username string
+	password string
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.username, t.password)
+	return t.next.RoundTrip(req)
+}
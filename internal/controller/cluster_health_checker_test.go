@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterHealthChecker", func() {
+	Context("When a cluster authenticates with OIDC", func() {
+		ctx := context.Background()
+
+		var (
+			issuer              *httptest.Server
+			discoveries, tokens atomic.Int32
+			audiences           []string
+		)
+
+		BeforeEach(func() {
+			discoveries.Store(0)
+			tokens.Store(0)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+				discoveries.Add(1)
+				_ = json.NewEncoder(w).Encode(map[string]string{"token_endpoint": issuer.URL + "/token"})
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				tokens.Add(1)
+				Expect(r.ParseForm()).To(Succeed())
+				audiences = r.PostForm["audience"]
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"access_token": "token",
+					"token_type":   "Bearer",
+					"expires_in":   3600,
+				})
+			})
+			issuer = httptest.NewServer(mux)
+		})
+
+		AfterEach(func() {
+			issuer.Close()
+		})
+
+		It("should reuse the token source of the cluster", func() {
+			healthChecker := NewClusterHealthChecker(k8sClient, HealthCheckerOptions{})
+			osc := &corev1alpha1.OrchestrationCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "oidc-cluster", Namespace: "default"},
+				Spec: corev1alpha1.OrchestrationClusterSpec{
+					Authentication: &corev1alpha1.Authentication{
+						OIDC: &corev1alpha1.OIDCAuthentication{
+							IssuerURL: issuer.URL,
+							ClientID:  "orchestration",
+							Audiences: []string{"orchestration-api", "zeebe-api"},
+						},
+					},
+				},
+			}
+
+			for range 2 {
+				source, err := healthChecker.TokenSource(ctx, osc, "secret")
+				Expect(err).NotTo(HaveOccurred())
+				token, err := source.Token()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.AccessToken).To(Equal("token"))
+			}
+			Expect(discoveries.Load()).To(Equal(int32(1)))
+			Expect(tokens.Load()).To(Equal(int32(1)))
+			Expect(audiences).To(Equal([]string{"orchestration-api", "zeebe-api"}))
+
+			By("Creating a new token source once the client secret changed")
+			source, err := healthChecker.TokenSource(ctx, osc, "rotated")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = source.Token()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(discoveries.Load()).To(Equal(int32(2)))
+			Expect(tokens.Load()).To(Equal(int32(2)))
+		})
+	})
+})
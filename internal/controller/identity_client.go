@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/identity"
+)
+
+// identityFinalizer holds back the deletion of users, groups and authorizations until they are
+// deleted in the cluster.
+const identityFinalizer = "core.camunda.io/identity-finalizer"
+
+// identityResyncInterval is the interval in which users, groups and authorizations are synced
+// again, which corrects changes made through the API or the web applications.
+const identityResyncInterval = 5 * time.Minute
+
+// identityCluster returns the cluster users, groups and authorizations are synced into, or nil if
+// it does not exist.
+func identityCluster(
+	ctx context.Context,
+	cli client.Client,
+	namespace, name string,
+) (*corev1alpha1.OrchestrationCluster, error) {
+	osc := new(corev1alpha1.OrchestrationCluster)
+	err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, osc)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return osc, nil
+}
+
+// newIdentityClient returns a client for the REST API of a cluster, authenticated as described at
+// restAPIClient.
+func newIdentityClient(
+	ctx context.Context,
+	healthChecker ClusterHealthChecker,
+	reader client.Reader,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*identity.Client, error) {
+	baseURL, httpClient, err := restAPIClient(ctx, healthChecker, reader, osc)
+	if err != nil {
+		return nil, err
+	}
+	return identity.NewClient(*baseURL, identity.WithHTTPClient(httpClient)), nil
+}
+
+// restAPIClient returns the URL of the REST API of a cluster and an HTTP client that authenticates
+// as the initial admin user with basic authentication, and with the client credentials of the
+// cluster with OIDC. The credentials are read with reader, which bypasses the cache.
+func restAPIClient(
+	ctx context.Context,
+	healthChecker ClusterHealthChecker,
+	reader client.Reader,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*url.URL, *http.Client, error) {
+	baseURL, httpClient, err := healthChecker.RESTAPI(ctx, osc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authentication := osc.Spec.Authentication
+	switch {
+	case authentication == nil:
+	case authentication.Basic != nil:
+		secret := authentication.Basic.AdminSecret.Name
+		username, err := secretValue(ctx, reader, osc.Namespace, secret, identity.AdminUsernameKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		password, err := secretValue(ctx, reader, osc.Namespace, secret, identity.AdminPasswordKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		httpClient = &http.Client{
+			Transport: &basicAuthTransport{
+				next:     transportOrDefault(httpClient.Transport),
+				username: string(username),
+				password: string(password),
+			},
+			Timeout: httpClient.Timeout,
+		}
+	case authentication.OIDC != nil:
+		httpClient, err = oidcHTTPClient(ctx, healthChecker, reader, osc, httpClient)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return baseURL, httpClient, nil
+}
+
+// transportOrDefault returns the transport, or the default transport if it is nil.
+func transportOrDefault(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		return http.DefaultTransport
+	}
+	return transport
+}
+
+// oidcHTTPClient returns an HTTP client that authenticates the requests of base with a token of
+// the client credentials grant of the identity provider of the cluster.
+func oidcHTTPClient(
+	ctx context.Context,
+	healthChecker ClusterHealthChecker,
+	reader client.Reader,
+	osc *corev1alpha1.OrchestrationCluster,
+	base *http.Client,
+) (*http.Client, error) {
+	oidc := osc.Spec.Authentication.OIDC
+	clientSecret, err := secretValue(ctx, reader, osc.Namespace, oidc.ClientSecret.Name, oidc.ClientSecret.Key)
+	if err != nil {
+		return nil, err
+	}
+	source, err := healthChecker.TokenSource(ctx, osc, string(clientSecret))
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &oauth2.Transport{Source: source, Base: base.Transport},
+		Timeout:   base.Timeout,
+	}, nil
+}
+
+// ensureIdentityFinalizer adds the finalizer to resources that do not have it yet.
+func ensureIdentityFinalizer(ctx context.Context, cli client.Client, obj client.Object) error {
+	if !controllerutil.AddFinalizer(obj, identityFinalizer) {
+		return nil
+	}
+	return cli.Update(ctx, obj)
+}
+
+// finalizeIdentity deletes a deleted user, group or authorization in the cluster and removes the
+// finalizer. Nothing is deleted if the cluster is gone or being deleted itself.
+func finalizeIdentity(
+	ctx context.Context,
+	cli client.Client,
+	healthChecker ClusterHealthChecker,
+	reader client.Reader,
+	obj client.Object,
+	osc *corev1alpha1.OrchestrationCluster,
+	deleteFromCluster func(*identity.Client) error,
+) error {
+	if !controllerutil.ContainsFinalizer(obj, identityFinalizer) {
+		return nil
+	}
+	if osc != nil && osc.DeletionTimestamp.IsZero() {
+		c, err := newIdentityClient(ctx, healthChecker, reader, osc)
+		if err != nil {
+			return err
+		}
+		if err := deleteFromCluster(c); err != nil {
+			return err
+		}
+	}
+	controllerutil.RemoveFinalizer(obj, identityFinalizer)
+	return cli.Update(ctx, obj)
+}
+
+// setSyncedCondition sets the Ready condition after a sync that ended with the error.
+func setSyncedCondition(conditions *[]metav1.Condition, generation int64, err error) {
+	condition := metav1.Condition{
+		Type:               corev1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             corev1alpha1.ReasonSynced,
+		Message:            "synced into the cluster",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = corev1alpha1.ReasonSyncFailed
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// setClusterNotFoundCondition sets the Ready condition while the cluster does not exist.
+func setClusterNotFoundCondition(conditions *[]metav1.Condition, generation int64, clusterName string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               corev1alpha1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             corev1alpha1.ReasonClusterNotFound,
+		Message:            fmt.Sprintf("cluster %s not found", clusterName),
+	})
+}
@@ -19,24 +19,41 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sLabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
 	"github.com/camunda/camunda-operator/pkg/bundles"
+	"github.com/camunda/camunda-operator/pkg/bundles/mycustom"
 	"github.com/camunda/camunda-operator/pkg/labels"
+	"github.com/camunda/camunda-operator/pkg/status"
 )
 
 // OrchestrationClusterReconciler reconciles a OrchestrationCluster object
 type OrchestrationClusterReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// HealthChecker reads the topology of the clusters and scales and upgrades them through their
+	// management API.
+	HealthChecker ClusterHealthChecker
+	// APIReader reads the credentials of the REST API without caching Secrets.
+	APIReader client.Reader
+	// ResyncInterval is the interval in which a cluster is reconciled even if nothing changed, which
+	// corrects drift and keeps its status fresh. Zero disables the resync.
+	ResyncInterval time.Duration
 }
 
 // nolint:lll
@@ -47,12 +64,19 @@ type OrchestrationClusterReconciler struct {
 // nolint:lll
 // +kubebuilder:rbac:groups=core,resources=services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
-// CRUD apps: statefulsets
+// CRUD apps: statefulsets, deployments
 // nolint:lll
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets/scale,verbs=get;update
 // +kubebuilder:rbac:groups=apps,resources=statefulsets/status,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+// nolint:lll
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
@@ -62,7 +86,8 @@ func (r *OrchestrationClusterReconciler) Reconcile(ctx context.Context, req ctrl
 	orchestrationCluster := new(corev1alpha1.OrchestrationCluster)
 	err := r.Get(ctx, req.NamespacedName, orchestrationCluster)
 	if err != nil {
-		return ctrl.Result{}, err
+		// The cluster is gone once its finalizer was removed.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
 	log := logf.FromContext(ctx,
@@ -70,83 +95,170 @@ func (r *OrchestrationClusterReconciler) Reconcile(ctx context.Context, req ctrl
 		"version", orchestrationCluster.Spec.Version,
 	)
 
-	bundle, err := bundles.New(*orchestrationCluster)
+	if !orchestrationCluster.DeletionTimestamp.IsZero() {
+		return r.finalize(ctx, orchestrationCluster)
+	}
+	if err := r.ensureFinalizer(ctx, orchestrationCluster); err != nil {
+		log.Error(err, "Failed to add finalizer to OrchestrationCluster")
+		return ctrl.Result{}, err
+	}
+
+	sts, err := r.lookupStatefulSet(ctx, orchestrationCluster)
 	if err != nil {
-		log.Error(err, "Error creating bundle for OrchestrationCluster")
+		log.Error(err, "Error looking up StatefulSet for OrchestrationCluster")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureBootstrapClusterSize(ctx, orchestrationCluster, sts); err != nil {
+		log.Error(err, "Failed to record bootstrap cluster size")
 		return ctrl.Result{}, err
 	}
 
+	scalingResult, err := r.reconcileScaling(ctx, orchestrationCluster, sts)
+	if err != nil {
+		return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonScalingFailed, err)
+	}
+
+	upgradeResult, err := r.reconcileUpgrade(ctx, orchestrationCluster, sts)
+	if err != nil {
+		return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonUpgradeFailed, err)
+	}
+
+	// The resources are built with the version the upgrade allows to roll out,
+	// which lags behind spec.version while an upgrade is blocked.
+	rendered := orchestrationCluster.DeepCopy()
+	rendered.Spec.Version = upgradeResult.Version
+
+	bundle, err := bundles.New(*rendered)
+	if err != nil {
+		return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonInvalidSpec, err)
+	}
+
 	resources, err := bundle.Resources()
 	if err != nil {
-		log.Error(err, "Error building resources for OrchestrationCluster")
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonInvalidSpec, err)
 	}
 
 	for _, resource := range resources {
+		if statefulSet, ok := resource.(*appsv1.StatefulSet); ok {
+			// The replicas are driven by the scaling state machine instead of the cluster size.
+			statefulSet.Spec.Replicas = ptr.To(scalingResult.Replicas)
+			keepVolumeClaimTemplates(statefulSet, sts)
+			// During an upgrade only the brokers from the partition on are rolled.
+			if upgradeResult.Partition != nil {
+				statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+					Type: appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+						Partition: upgradeResult.Partition,
+					},
+				}
+			}
+		}
+
+		kind := resource.GetObjectKind().GroupVersionKind().Kind
+
 		// Create or update the resource
 		if err := ctrl.SetControllerReference(orchestrationCluster, resource, r.Scheme); err != nil {
-			log.Error(err, "Failed to set controller reference", "resource", resource.GetName())
-			return ctrl.Result{}, err
+			err = fmt.Errorf("failed to set controller reference on %s %s: %w", kind, resource.GetName(), err)
+			return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonApplyFailed, err)
 		}
 
-		merged := k8sLabels.Merge(resource.GetLabels(), labels.Create(orchestrationCluster))
+		// The labels rendered by the bundle win, e.g. the component of the standalone gateways.
+		merged := k8sLabels.Merge(labels.Create(rendered), resource.GetLabels())
 		resource.SetLabels(merged)
 
-		if err := r.Patch(
+		err := r.Patch(
 			ctx,
 			resource,
 			client.Apply,
 			client.ForceOwnership,
 			client.FieldOwner("orchestrationcluster-controller"),
-		); err != nil {
-			log.Error(err, "Failed to create or patch resource", "resource", resource.GetName())
-			return ctrl.Result{}, err
+		)
+		if meta.IsNoMatchError(err) && resource.GetObjectKind().GroupVersionKind().Group == monitoringGroup {
+			log.Info("Skipping monitor, the Prometheus Operator CRDs are not installed", "kind", kind)
+			r.Recorder.Eventf(orchestrationCluster, corev1.EventTypeWarning, "MonitoringUnavailable",
+				"Skipping %s %s, the Prometheus Operator CRDs are not installed", kind, resource.GetName())
+			continue
 		}
+		if err != nil {
+			err = fmt.Errorf("failed to apply %s %s: %w", kind, resource.GetName(), err)
+			return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonApplyFailed, err)
+		}
+	}
+
+	if err := r.deleteStaleResources(ctx, orchestrationCluster, resources); err != nil {
+		return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonPruneFailed, err)
 	}
 
-	err = r.checkCamunda(ctx, orchestrationCluster)
+	storageRequeue, err := r.reconcileStorage(ctx, orchestrationCluster, scalingResult.Replicas)
 	if err != nil {
+		return ctrl.Result{}, r.reportReconcileError(ctx, orchestrationCluster, corev1alpha1.ReasonStorageFailed, err)
+	}
+
+	// The topology is unavailable until the brokers started, so the error is reported in the
+	// status and as event but not returned; the cluster is checked again with the backoff below.
+	if err := r.checkCamunda(ctx, orchestrationCluster); err != nil {
 		log.Error(err, "Error checking Camunda")
+		r.Recorder.Event(orchestrationCluster, corev1.EventTypeWarning, corev1alpha1.ReasonTopologyUnavailable, err.Error())
 	}
 
-	return ctrl.Result{}, nil
+	// A cluster that is not ready is checked again with a backoff until it converged.
+	return ctrl.Result{RequeueAfter: shortestRequeue(
+		scalingResult.RequeueAfter,
+		upgradeResult.RequeueAfter,
+		storageRequeue,
+		status.Backoff(&orchestrationCluster.Status, time.Now()),
+		r.ResyncInterval,
+	)}, nil
+}
+
+// shortestRequeue returns the shortest non-zero duration, or zero if there is none.
+func shortestRequeue(durations ...time.Duration) time.Duration {
+	var shortest time.Duration
+	for _, d := range durations {
+		if d > 0 && (shortest == 0 || d < shortest) {
+			shortest = d
+		}
+	}
+	return shortest
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OrchestrationClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1alpha1.OrchestrationCluster{}).
 		Named("orchestrationcluster").
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
-		Complete(r)
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&policyv1.PodDisruptionBudget{})
+
+	// Optional kinds like the Gateway API routes are only watched if their CRDs are installed.
+	for _, gvk := range installedKinds(mgr.GetRESTMapper()) {
+		owned := &unstructured.Unstructured{}
+		owned.SetGroupVersionKind(gvk)
+		builder = builder.Owns(owned)
+	}
+	return builder.Complete(r)
 }
 
+// lookupService returns the headless Service of the brokers if it exposes the port. The Service is
+// looked up by name, the Service of the standalone gateways exposes the management port as well.
 func lookupService(
 	ctx context.Context,
 	cli client.Client,
 	cluster *corev1alpha1.OrchestrationCluster,
 	desiredPort int32,
 ) (*corev1.Service, error) {
-	selector := client.MatchingLabels(labels.CreateSelector(cluster))
-	var svcList corev1.ServiceList
-	if err := cli.List(ctx, &svcList, selector); err != nil {
-		return nil, err
-	}
-	if len(svcList.Items) == 0 {
-		return nil, fmt.Errorf("no service found for cluster %s", cluster.Name)
+	svc := &corev1.Service{}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: mycustom.HeadlessServiceName(*cluster)}
+	if err := cli.Get(ctx, key, svc); err != nil {
+		return nil, fmt.Errorf("failed to get service %s: %w", key.Name, err)
 	}
-	svc := &svcList.Items[0]
-	var svcName string
 	for _, port := range svc.Spec.Ports {
 		if port.Port == desiredPort {
-			svcName = svc.Name
-			break
+			return svc, nil
 		}
 	}
-	if svcName == "" {
-		return nil, fmt.Errorf("no service port 9600 found for cluster %s", cluster.Name)
-	}
-
-	return svc, nil
+	return nil, fmt.Errorf("no service port %d found for cluster %s", desiredPort, cluster.Name)
 }
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/status"
+)
+
+// Reasons of the Normal events emitted for scaling operations and upgrades.
+const (
+	eventReasonScaling   = "Scaling"
+	eventReasonScaled    = "Scaled"
+	eventReasonUpgrading = "Upgrading"
+	eventReasonUpgraded  = "Upgraded"
+)
+
+// reportReconcileError records a failed reconciliation step as a Warning event and as the
+// ReconcileError condition, so it shows up in kubectl describe. It returns err.
+func (r *OrchestrationClusterReconciler) reportReconcileError(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	reason string,
+	err error,
+) error {
+	logger := log.FromContext(ctx)
+	logger.Error(err, "Failed to reconcile OrchestrationCluster", "reason", reason)
+	r.Recorder.Event(osc, corev1.EventTypeWarning, reason, err.Error())
+
+	observed := osc.Status.DeepCopy()
+	setConditions(observed, []metav1.Condition{status.ReconcileError(osc, reason, err)})
+	if updateErr := r.updateStatus(ctx, osc, observed); updateErr != nil {
+		logger.Error(updateErr, "Failed to record reconcile error in status")
+	}
+	return err
+}
+
+// recordScalingEvent emits an event when a scaling operation starts, moves to another phase or
+// ends. Progress within a phase, such as a new change id, does not emit an event.
+func (r *OrchestrationClusterReconciler) recordScalingEvent(
+	osc *corev1alpha1.OrchestrationCluster,
+	previous, current *corev1alpha1.ScalingStatus,
+) {
+	switch {
+	case previous != nil && current != nil && previous.Phase == current.Phase:
+	case current != nil:
+		r.Recorder.Eventf(osc, corev1.EventTypeNormal, eventReasonScaling, "Scaling from %d to %d brokers: %s",
+			current.FromClusterSize, current.ToClusterSize, current.Phase)
+	case previous != nil:
+		r.Recorder.Eventf(osc, corev1.EventTypeNormal, eventReasonScaled, "Scaled from %d to %d brokers",
+			previous.FromClusterSize, previous.ToClusterSize)
+	}
+}
+
+// recordUpgradeEvent emits an event when an upgrade rolls another broker or ends, and a Warning
+// event when the upgrade gets blocked.
+func (r *OrchestrationClusterReconciler) recordUpgradeEvent(
+	osc *corev1alpha1.OrchestrationCluster,
+	previous, current *corev1alpha1.UpgradeStatus,
+	wasBlocked bool,
+	blocked metav1.Condition,
+) {
+	switch {
+	case equality.Semantic.DeepEqual(previous, current):
+	case current != nil:
+		r.Recorder.Eventf(osc, corev1.EventTypeNormal, eventReasonUpgrading, "Upgrading from %s to %s: broker %d",
+			current.FromVersion, current.ToVersion, current.Partition)
+	case previous != nil:
+		r.Recorder.Eventf(osc, corev1.EventTypeNormal, eventReasonUpgraded, "Upgraded from %s to %s",
+			previous.FromVersion, previous.ToVersion)
+	}
+	if !wasBlocked && blocked.Status == metav1.ConditionTrue {
+		r.Recorder.Event(osc, corev1.EventTypeWarning, blocked.Reason, blocked.Message)
+	}
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/internal/metrics"
+	"github.com/camunda/camunda-operator/pkg/labels"
+	"github.com/camunda/camunda-operator/pkg/storage"
+)
+
+// clusterFinalizer holds back the deletion of an OrchestrationCluster until the brokers are stopped
+// and the deletion policy is applied to their data volumes.
+const clusterFinalizer = "core.camunda.io/finalizer"
+
+// teardownPollInterval is the interval in which the progress of the teardown is checked.
+const teardownPollInterval = 5 * time.Second
+
+// nolint:lll
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create
+
+// ensureFinalizer adds the finalizer to clusters that do not have it yet.
+func (r *OrchestrationClusterReconciler) ensureFinalizer(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) error {
+	if !controllerutil.AddFinalizer(osc, clusterFinalizer) {
+		return nil
+	}
+	return r.Update(ctx, osc)
+}
+
+// finalize tears down a deleted cluster in order: the brokers are stopped first so their data is
+// no longer written, then the deletion policy is applied to the data volumes. The finalizer is
+// removed last, the remaining resources are garbage collected through their owner references.
+func (r *OrchestrationClusterReconciler) finalize(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(osc, clusterFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx)
+
+	stopped, err := r.stopBrokers(ctx, osc)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to stop brokers of cluster %s: %w", osc.Name, err)
+	}
+	if !stopped {
+		logger.Info("Waiting for brokers to stop before releasing their volumes")
+		return ctrl.Result{RequeueAfter: teardownPollInterval}, nil
+	}
+
+	released, err := r.releaseVolumes(ctx, osc)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply deletion policy %s to cluster %s: %w",
+			storage.DeletionPolicy(osc), osc.Name, err)
+	}
+	if !released {
+		logger.Info("Waiting for data volumes to be released", "deletionPolicy", storage.DeletionPolicy(osc))
+		return ctrl.Result{RequeueAfter: teardownPollInterval}, nil
+	}
+
+	logger.Info("Cluster torn down", "deletionPolicy", storage.DeletionPolicy(osc))
+	controllerutil.RemoveFinalizer(osc, clusterFinalizer)
+	metrics.ForgetCluster(osc.Namespace, osc.Name)
+	r.HealthChecker.Forget(osc)
+	return ctrl.Result{}, r.Update(ctx, osc)
+}
+
+// stopBrokers deletes the StatefulSet and reports whether all broker pods are gone.
+func (r *OrchestrationClusterReconciler) stopBrokers(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (bool, error) {
+	sts, err := r.lookupStatefulSet(ctx, osc)
+	if err != nil {
+		return false, err
+	}
+	if sts != nil && sts.DeletionTimestamp.IsZero() {
+		if err := r.Delete(ctx, sts); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods,
+		client.InNamespace(osc.Namespace),
+		client.MatchingLabels(labels.CreateSelector(osc)),
+	); err != nil {
+		return false, err
+	}
+	return len(pods.Items) == 0, nil
+}
+
+// releaseVolumes applies the deletion policy to the data volume claims of the brokers and reports
+// whether all of them are released.
+func (r *OrchestrationClusterReconciler) releaseVolumes(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (bool, error) {
+	var claims corev1.PersistentVolumeClaimList
+	if err := r.List(ctx, &claims,
+		client.InNamespace(osc.Namespace),
+		client.MatchingLabels(labels.CreateSelector(osc)),
+	); err != nil {
+		return false, err
+	}
+
+	released := true
+	for i := range claims.Items {
+		pvc := &claims.Items[i]
+		if !storage.IsDataClaim(osc, pvc) || !pvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		switch storage.DeletionPolicy(osc) {
+		case corev1alpha1.DeletionPolicyDelete:
+			if err := r.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+		case corev1alpha1.DeletionPolicySnapshot:
+			ready, err := r.snapshotClaim(ctx, osc, pvc)
+			if err != nil {
+				return false, err
+			}
+			if !ready {
+				released = false
+				continue
+			}
+			if err := r.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+		default:
+			if pvc.Annotations[storage.RetainedFromAnnotation] == storage.RetainedFrom(osc) {
+				continue
+			}
+			patch := client.MergeFrom(pvc.DeepCopy())
+			storage.RetainClaim(osc, pvc)
+			if err := r.Patch(ctx, pvc, patch); err != nil {
+				return false, err
+			}
+		}
+	}
+	return released, nil
+}
+
+// snapshotClaim takes the final snapshot of the claim and reports whether it is ready to use.
+func (r *OrchestrationClusterReconciler) snapshotClaim(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	pvc *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(storage.VolumeSnapshotGVK)
+	key := types.NamespacedName{Namespace: pvc.Namespace, Name: storage.SnapshotName(osc, pvc)}
+	err := r.Get(ctx, key, snapshot)
+	if apierrors.IsNotFound(err) {
+		log.FromContext(ctx).Info("Taking final snapshot of data volume", "claim", pvc.Name, "snapshot", key.Name)
+		return false, r.Create(ctx, storage.VolumeSnapshot(osc, pvc))
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if message := storage.SnapshotError(snapshot); message != "" {
+		log.FromContext(ctx).Info("Snapshot of data volume failed, retrying", "claim", pvc.Name,
+			"snapshot", key.Name, "error", message)
+	}
+	return storage.SnapshotReady(snapshot), nil
+}
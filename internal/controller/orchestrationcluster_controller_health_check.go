@@ -3,82 +3,141 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/sijoma/camunda-go-sdk/management"
+	"github.com/sijoma/camunda-go-sdk/orchestration"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/internal/metrics"
+	"github.com/camunda/camunda-operator/pkg/status"
 )
 
 func (r *OrchestrationClusterReconciler) checkCamunda(
 	ctx context.Context,
 	osc *corev1alpha1.OrchestrationCluster,
 ) error {
-	actuatorPort := int32(9600)
-	svc, err := lookupService(ctx, r.Client, osc, actuatorPort)
+	sts, err := r.lookupStatefulSet(ctx, osc)
 	if err != nil {
-		return fmt.Errorf("failed to lookup service for osc %s: %w", osc.Name, err)
+		return fmt.Errorf("failed to lookup statefulset for osc %s: %w", osc.Name, err)
 	}
 
-	actuatorURL := &url.URL{
-		Scheme: "http",
-		Host:   fmt.Sprintf("%s.%s.svc.cluster.local:%d", svc.Name, svc.Namespace, actuatorPort),
-	}
+	observed := osc.Status.DeepCopy()
 
-	managementClient, err := management.NewClient(
-		management.WithBaseURL(*actuatorURL),
-	)
+	topo, err := r.fetchTopology(ctx, osc)
 	if err != nil {
+		setConditions(observed, status.TopologyUnavailable(osc, err))
+		setConditions(observed, []metav1.Condition{
+			status.ReconcileError(osc, corev1alpha1.ReasonTopologyUnavailable, fmt.Errorf("failed to fetch topology: %w", err)),
+		})
+		if updateErr := r.updateStatus(ctx, osc, observed); updateErr != nil {
+			return updateErr
+		}
 		return err
 	}
 
-	topo, err := managementClient.Cluster.Topology(ctx)
-	if err != nil {
-		return err
+	if len(topo.PendingChange.Pending) > 0 {
+		log.FromContext(ctx).Info("Cluster topology is changing", "pendingChanges", topo.PendingChange.Pending)
 	}
 
-	// Check if the osc is ready
-	ready := false
+	metrics.RecordHealthCheck(osc.Namespace, osc.Name, time.Now())
+	replicaHealth := r.fetchReplicaHealth(ctx, osc)
+	status.ObserveTopology(observed, osc, topo, replicaHealth)
+	setConditions(observed, status.Conditions(osc, topo, replicaHealth, sts))
+	setConditions(observed, []metav1.Condition{status.Reconciled(osc)})
+	return r.updateStatus(ctx, osc, observed)
+}
 
-	if len(topo.PendingChange.Pending) > 0 {
-		// If there are pending changes, we can assume that the osc is scaling.
-		// We can update the status or log this information as needed.
-		log.FromContext(ctx).Info("Cluster is scaling", "pendingChanges", topo.PendingChange.Pending)
-	} else {
-		log.FromContext(ctx).Info("No pending changes in osc topology")
+func (r *OrchestrationClusterReconciler) fetchTopology(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*management.TopologyResponse, error) {
+	start := time.Now()
+	topo, err := r.HealthChecker.Topology(ctx, osc)
+	metrics.ObserveActuatorCall(metrics.OperationTopology, start, err)
+	return topo, err
+}
+
+// fetchReplicaHealth returns the role and health of the partition replicas from the topology of the
+// REST API, or nil if the REST API is unavailable. The partitions are then judged by their replicas
+// alone.
+func (r *OrchestrationClusterReconciler) fetchReplicaHealth(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) *status.ReplicaHealth {
+	baseURL, httpClient, err := restAPIClient(ctx, r.HealthChecker, r.APIReader, osc)
+	if err == nil {
+		var topo *orchestration.TopologyResponse
+		topo, err = fetchRESTTopology(ctx, baseURL, httpClient)
+		if err == nil {
+			return status.NewReplicaHealth(topo)
+		}
 	}
+	log.FromContext(ctx).V(1).Info("Partition roles and health are unavailable", "error", err.Error())
+	return nil
+}
 
-	if len(topo.Brokers) != int(osc.Spec.ClusterSize) {
-		log.FromContext(ctx).
-			Info("Cluster size does not match desired size",
-				"desiredSize", osc.Spec.ClusterSize,
-				"currentSize", len(topo.Brokers))
+func fetchRESTTopology(
+	ctx context.Context,
+	baseURL *url.URL,
+	httpClient *http.Client,
+) (*orchestration.TopologyResponse, error) {
+	// The client of the SDK has no timeout, so the timeout of the REST API applies to the context.
+	if httpClient.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, httpClient.Timeout)
+		defer cancel()
 	}
+	cli, err := orchestration.NewClient(
+		orchestration.WithBaseURL(*baseURL),
+		orchestration.WithTransport(transportOrDefault(httpClient.Transport)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Cluster.Topology(ctx)
+}
 
-	// TODO: Implement proper status
-	if len(topo.Brokers) == int(osc.Spec.ClusterSize) && topo.Version > 0 {
-		ready = true
+// lookupStatefulSet returns the broker StatefulSet of the cluster or nil if it does not exist yet.
+func (r *OrchestrationClusterReconciler) lookupStatefulSet(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+) (*appsv1.StatefulSet, error) {
+	sts := new(appsv1.StatefulSet)
+	err := r.Get(ctx, client.ObjectKey{Namespace: osc.Namespace, Name: osc.Name}, sts)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
 	}
-	conditionStatus := metav1.ConditionTrue
-	if ready {
-		conditionStatus = metav1.ConditionTrue
+	if err != nil {
+		return nil, err
 	}
-	changed := meta.SetStatusCondition(&osc.Status.Conditions, metav1.Condition{
-		Type:               "Ready",
-		Status:             conditionStatus,
-		ObservedGeneration: osc.Generation,
-		Reason:             "CamundaReplicasReady",
-		Message:            "replicas are ready",
-	})
-	if changed {
-		err = r.Status().Update(ctx, osc)
-		if err != nil {
-			return err
-		}
+	return sts, nil
+}
+
+func setConditions(observed *corev1alpha1.OrchestrationClusterStatus, conditions []metav1.Condition) {
+	for _, condition := range conditions {
+		meta.SetStatusCondition(&observed.Conditions, condition)
 	}
+}
 
-	return nil
+// updateStatus writes the observed status if it differs from the current status of the cluster.
+func (r *OrchestrationClusterReconciler) updateStatus(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	observed *corev1alpha1.OrchestrationClusterStatus,
+) error {
+	if equality.Semantic.DeepEqual(osc.Status, *observed) {
+		return nil
+	}
+	osc.Status = *observed
+	return r.Status().Update(ctx, osc)
 }
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/labels"
+)
+
+// nolint:lll
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
+
+// optionalKinds are the kinds of resources the bundle only builds for some clusters. Resources of
+// these kinds the bundle no longer builds are deleted.
+var optionalKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"},
+	{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GRPCRoute"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"},
+}
+
+// monitoringGroup is the API group of the Prometheus Operator. Monitors are skipped if its CRDs are
+// not installed, as scraping the cluster is not needed for it to work.
+const monitoringGroup = "monitoring.coreos.com"
+
+// deleteStaleResources deletes the resources of optional kinds that are controlled by the cluster
+// but not part of the desired resources, e.g. the Ingresses after switching to the Gateway API.
+// Kinds whose CRDs are not installed are skipped.
+func (r *OrchestrationClusterReconciler) deleteStaleResources(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	desired []client.Object,
+) error {
+	logger := log.FromContext(ctx)
+
+	wanted := make(map[schema.GroupVersionKind]map[string]bool, len(optionalKinds))
+	for _, resource := range desired {
+		gvk := resource.GetObjectKind().GroupVersionKind()
+		if wanted[gvk] == nil {
+			wanted[gvk] = map[string]bool{}
+		}
+		wanted[gvk][resource.GetName()] = true
+	}
+
+	// The resources of the standalone gateways differ in the component, so all components are listed.
+	selector := client.MatchingLabels(labels.CreateClusterSelector(osc))
+	for _, gvk := range optionalKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := r.List(ctx, list, client.InNamespace(osc.Namespace), selector)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list %s resources: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			if wanted[gvk][item.GetName()] || !metav1.IsControlledBy(item, osc) {
+				continue
+			}
+			logger.Info("Deleting stale resource", "kind", gvk.Kind, "resource", item.GetName())
+			if err := r.Delete(ctx, item); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete stale %s %s: %w", gvk.Kind, item.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// installedKinds returns the optional kinds whose resources are served by the API server, the
+// others cannot be watched.
+func installedKinds(mapper meta.RESTMapper) []schema.GroupVersionKind {
+	var installed []schema.GroupVersionKind
+	for _, gvk := range optionalKinds {
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			installed = append(installed, gvk)
+		}
+	}
+	return installed
+}
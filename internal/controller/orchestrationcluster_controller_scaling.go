@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/scaling"
+)
+
+// clusterSizeEnv configures the number of brokers a cluster is bootstrapped with.
+const clusterSizeEnv = "ZEEBE_BROKER_CLUSTER_CLUSTER_SIZE"
+
+// reconcileScaling advances a broker scaling operation and returns the number of replicas the
+// StatefulSet must have. The scaling state is persisted in the status before the StatefulSet is
+// changed, so the operation can be resumed after an operator restart.
+func (r *OrchestrationClusterReconciler) reconcileScaling(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	sts *appsv1.StatefulSet,
+) (scaling.Result, error) {
+	if !scaling.InProgress(osc, sts) {
+		return scaling.Result{Replicas: osc.Spec.ClusterSize}, nil
+	}
+
+	cluster, err := r.HealthChecker.ManagementAPI(ctx, osc)
+	if err != nil {
+		return scaling.Result{}, err
+	}
+
+	result, err := scaling.Step(ctx, osc, sts, cluster)
+	if err != nil {
+		return scaling.Result{}, fmt.Errorf("failed to scale cluster %s: %w", osc.Name, err)
+	}
+
+	if !equality.Semantic.DeepEqual(osc.Status.Scaling, result.Scaling) {
+		log.FromContext(ctx).Info("Scaling cluster",
+			"replicas", result.Replicas,
+			"scaling", result.Scaling,
+		)
+		r.recordScalingEvent(osc, osc.Status.Scaling, result.Scaling)
+		osc.Status.Scaling = result.Scaling
+		if err := r.Status().Update(ctx, osc); err != nil {
+			return scaling.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// ensureBootstrapClusterSize records the cluster size the brokers are configured with, so that
+// later changes of spec.clusterSize are applied by scaling the topology instead of rolling the
+// brokers. Clusters created before the size was recorded keep the size their pods run with.
+func (r *OrchestrationClusterReconciler) ensureBootstrapClusterSize(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	sts *appsv1.StatefulSet,
+) error {
+	if osc.Status.BootstrapClusterSize > 0 {
+		return nil
+	}
+	osc.Status.BootstrapClusterSize = configuredClusterSize(sts, osc.Spec.ClusterSize)
+	return r.Status().Update(ctx, osc)
+}
+
+// configuredClusterSize returns the cluster size the pods of the StatefulSet are configured with,
+// or fallback if there is no StatefulSet yet.
+func configuredClusterSize(sts *appsv1.StatefulSet, fallback int32) int32 {
+	if sts == nil {
+		return fallback
+	}
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name != clusterSizeEnv {
+				continue
+			}
+			if size, err := strconv.ParseInt(env.Value, 10, 32); err == nil && size > 0 {
+				return int32(size)
+			}
+		}
+	}
+	if sts.Spec.Replicas != nil && *sts.Spec.Replicas > 0 {
+		return *sts.Spec.Replicas
+	}
+	return fallback
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/storage"
+)
+
+// reconcileStorage expands the data volumes of the brokers to the requested size and reports
+// their state in the status. The volume claim templates of a StatefulSet are immutable, so the
+// existing claims are resized in place.
+func (r *OrchestrationClusterReconciler) reconcileStorage(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	replicas int32,
+) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+	size := storage.Size(osc)
+
+	var volumes []corev1alpha1.VolumeStatus
+	for broker := int32(0); broker < replicas; broker++ {
+		pvc := &corev1.PersistentVolumeClaim{}
+		key := types.NamespacedName{Namespace: osc.Namespace, Name: storage.ClaimName(osc.Name, broker)}
+		if err := r.Get(ctx, key, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The StatefulSet did not create the claim yet.
+				continue
+			}
+			return 0, fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", key.Name, err)
+		}
+
+		var resizeErr error
+		if storage.NeedsExpansion(pvc, size) {
+			logger.Info("Expanding data volume", "claim", pvc.Name, "size", size.String())
+			resizeErr = r.expandClaim(ctx, pvc, size)
+		}
+		volumes = append(volumes, storage.Observe(broker, pvc, size, resizeErr))
+	}
+
+	if !equality.Semantic.DeepEqual(osc.Status.Volumes, volumes) {
+		osc.Status.Volumes = volumes
+		if err := r.Status().Update(ctx, osc); err != nil {
+			return 0, err
+		}
+	}
+
+	if storage.Expanding(volumes) {
+		return storage.PollInterval, nil
+	}
+	return 0, nil
+}
+
+// expandClaim requests the new size on the claim.
+func (r *OrchestrationClusterReconciler) expandClaim(
+	ctx context.Context,
+	pvc *corev1.PersistentVolumeClaim,
+	size resource.Quantity,
+) error {
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	return r.Patch(ctx, pvc, patch)
+}
+
+// keepVolumeClaimTemplates carries the volume claim templates of the existing StatefulSet over,
+// since they cannot be changed. Size changes are applied to the claims by reconcileStorage.
+func keepVolumeClaimTemplates(desired, existing *appsv1.StatefulSet) {
+	if existing == nil || len(existing.Spec.VolumeClaimTemplates) == 0 {
+		return
+	}
+	desired.Spec.VolumeClaimTemplates = existing.Spec.VolumeClaimTemplates
+}
@@ -18,16 +18,30 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //nolint:revive
 	. "github.com/onsi/gomega"    //nolint:revive
+	"github.com/sijoma/camunda-go-sdk/management"
+	"golang.org/x/oauth2"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/backup"
+	"github.com/camunda/camunda-operator/pkg/scaling"
+	"github.com/camunda/camunda-operator/pkg/status"
 )
 
 var _ = Describe("OrchestrationCluster Controller", func() {
@@ -73,19 +87,258 @@ var _ = Describe("OrchestrationCluster Controller", func() {
 
 			By("Cleanup the specific resource instance OrchestrationCluster")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Reconciling the deleted resource to release the finalizer")
+			controllerReconciler := &OrchestrationClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Recorder:      record.NewFakeRecorder(100),
+				HealthChecker: unavailableHealthChecker(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
 
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &OrchestrationClusterReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Recorder:      record.NewFakeRecorder(100),
+				HealthChecker: unavailableHealthChecker(),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+
+			By("Adding the finalizer")
+			resource := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Finalizers).To(ContainElement(clusterFinalizer))
+		})
+
+		It("should requeue with a backoff while the cluster is not ready", func() {
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler := &OrchestrationClusterReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				Recorder:       recorder,
+				HealthChecker:  unavailableHealthChecker(),
+				ResyncInterval: 10 * time.Minute,
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", status.MinBackoff))
+
+			By("Reporting the unavailable topology")
+			resource := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, corev1alpha1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, corev1alpha1.ConditionReconcileError)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(corev1alpha1.ReasonTopologyUnavailable)))
+		})
+
+		It("should delete the Ingress once the cluster is no longer exposed", func() {
+			controllerReconciler := &OrchestrationClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Recorder:      record.NewFakeRecorder(100),
+				HealthChecker: unavailableHealthChecker(),
+			}
+			ingressKey := types.NamespacedName{Name: resourceName + "-core-http", Namespace: "default"}
+
+			By("Exposing the cluster with an Ingress")
+			resource := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Exposure = &corev1alpha1.Exposure{
+				Ingress: &corev1alpha1.IngressExposure{
+					HTTP: &corev1alpha1.ExposedEndpoint{Host: "camunda.example.com"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, ingressKey, &networkingv1.Ingress{})).To(Succeed())
+
+			By("Removing the exposure")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Exposure = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, ingressKey, &networkingv1.Ingress{}))).To(BeTrue())
+		})
+
+		It("should observe the topology reported by the health checker", func() {
+			controllerReconciler := &OrchestrationClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Recorder:      record.NewFakeRecorder(100),
+				HealthChecker: &fakeHealthChecker{topology: healthyTopology(3, 3)},
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ObservedClusterSize).To(Equal(int32(3)))
+			Expect(resource.Status.Partitions).To(HaveLen(3))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, corev1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, corev1alpha1.ConditionReconcileError)).To(BeTrue())
+		})
+
+		It("should skip the monitor if the Prometheus Operator CRDs are not installed", func() {
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler := &OrchestrationClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Recorder:      recorder,
+				HealthChecker: unavailableHealthChecker(),
+			}
+
+			resource := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Monitoring = &corev1alpha1.Monitoring{Kind: corev1alpha1.ServiceMonitorKind}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("MonitoringUnavailable")))
+		})
+
+		It("should keep the standalone gateways apart from the brokers", func() {
+			controllerReconciler := &OrchestrationClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Recorder:      record.NewFakeRecorder(100),
+				HealthChecker: unavailableHealthChecker(),
+			}
+
+			resource := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.StandaloneGateway = &corev1alpha1.StandaloneGateway{}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Keeping the component of the gateway resources")
+			deployment := &appsv1.Deployment{}
+			key := types.NamespacedName{Name: resourceName + "-gateway", Namespace: "default"}
+			Expect(k8sClient.Get(ctx, key, deployment)).To(Succeed())
+			Expect(deployment.Labels).To(HaveKeyWithValue("app.kubernetes.io/component", "gateway"))
+
+			By("Reaching the management API through the headless Service of the brokers")
+			svc, err := lookupService(ctx, k8sClient, resource, actuator.Port)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(svc.Name).To(Equal(resourceName + "-core-headless"))
+		})
+	})
+
+	Context("When recording scaling events", func() {
+		It("should only emit an event when the phase changes", func() {
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler := &OrchestrationClusterReconciler{Recorder: recorder}
+			osc := &corev1alpha1.OrchestrationCluster{}
+
+			requesting := &corev1alpha1.ScalingStatus{
+				Phase:           corev1alpha1.ScalingPhaseRequestingChange,
+				FromClusterSize: 3,
+				ToClusterSize:   5,
+			}
+			awaiting := requesting.DeepCopy()
+			awaiting.Phase = corev1alpha1.ScalingPhaseAwaitingChange
+			progressed := awaiting.DeepCopy()
+			progressed.ChangeID = 7
+			progressed.Message = "2 operation(s) pending"
+
+			controllerReconciler.recordScalingEvent(osc, nil, requesting)
+			Expect(recorder.Events).To(Receive(ContainSubstring("RequestingChange")))
+			controllerReconciler.recordScalingEvent(osc, requesting, awaiting)
+			Expect(recorder.Events).To(Receive(ContainSubstring("AwaitingChange")))
+			controllerReconciler.recordScalingEvent(osc, awaiting, progressed)
+			Expect(recorder.Events).NotTo(Receive())
+			controllerReconciler.recordScalingEvent(osc, progressed, nil)
+			Expect(recorder.Events).To(Receive(ContainSubstring("Scaled from 3 to 5 brokers")))
 		})
 	})
 })
+
+// fakeHealthChecker reports a fixed topology instead of calling the management API of a cluster.
+type fakeHealthChecker struct {
+	topology *management.TopologyResponse
+	actuator backup.Actuator
+	err      error
+}
+
+// unavailableHealthChecker returns a health checker of a cluster whose brokers did not start yet.
+func unavailableHealthChecker() *fakeHealthChecker {
+	return &fakeHealthChecker{err: fmt.Errorf("dial tcp: connection refused")}
+}
+
+func (f *fakeHealthChecker) Topology(
+	context.Context,
+	*corev1alpha1.OrchestrationCluster,
+) (*management.TopologyResponse, error) {
+	return f.topology, f.err
+}
+
+func (f *fakeHealthChecker) ManagementAPI(
+	context.Context,
+	*corev1alpha1.OrchestrationCluster,
+) (scaling.Cluster, error) {
+	return nil, fmt.Errorf("the fake health checker does not support scaling")
+}
+
+func (f *fakeHealthChecker) Actuator(
+	context.Context,
+	*corev1alpha1.OrchestrationCluster,
+) (backup.Actuator, error) {
+	if f.actuator == nil {
+		return nil, fmt.Errorf("the fake health checker does not support backups")
+	}
+	return f.actuator, nil
+}
+
+func (f *fakeHealthChecker) RESTAPI(
+	context.Context,
+	*corev1alpha1.OrchestrationCluster,
+) (*url.URL, *http.Client, error) {
+	return nil, nil, fmt.Errorf("the fake health checker does not support the REST API")
+}
+
+func (f *fakeHealthChecker) TokenSource(
+	context.Context,
+	*corev1alpha1.OrchestrationCluster,
+	string,
+) (oauth2.TokenSource, error) {
+	return nil, fmt.Errorf("the fake health checker does not support OIDC")
+}
+
+func (f *fakeHealthChecker) Forget(*corev1alpha1.OrchestrationCluster) {}
+
+// healthyTopology returns a topology where every broker replicates every partition.
+func healthyTopology(brokers, partitions int) *management.TopologyResponse {
+	topo := &management.TopologyResponse{Version: 1}
+	for broker := 0; broker < brokers; broker++ {
+		state := management.BrokerState{ID: management.BrokerId(broker), State: management.BrokerStateActive}
+		for partition := 1; partition <= partitions; partition++ {
+			state.Partitions = append(state.Partitions, management.PartitionState{
+				ID:    management.PartitionId(partition),
+				State: management.PartitionStateActive,
+			})
+		}
+		topo.Brokers = append(topo.Brokers, state)
+	}
+	return topo
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/upgrade"
+)
+
+// reconcileUpgrade advances a rolling version upgrade and returns the version to build the
+// cluster resources with, together with the partition of the StatefulSet rolling update.
+func (r *OrchestrationClusterReconciler) reconcileUpgrade(
+	ctx context.Context,
+	osc *corev1alpha1.OrchestrationCluster,
+	sts *appsv1.StatefulSet,
+) (upgrade.Result, error) {
+	// The topology is only needed while brokers are upgraded.
+	var cluster upgrade.Cluster
+	if osc.Status.Upgrade != nil {
+		managementAPI, err := r.HealthChecker.ManagementAPI(ctx, osc)
+		if err != nil {
+			return upgrade.Result{}, err
+		}
+		cluster = managementAPI
+	}
+
+	result, err := upgrade.Step(ctx, osc, sts, cluster, time.Now())
+	if err != nil {
+		return upgrade.Result{}, fmt.Errorf("failed to upgrade cluster %s: %w", osc.Name, err)
+	}
+
+	observed := osc.Status.DeepCopy()
+	observed.Version = result.RunningVersion
+	observed.Upgrade = result.Upgrade
+	meta.SetStatusCondition(&observed.Conditions, result.Blocked)
+	if !equality.Semantic.DeepEqual(osc.Status, *observed) {
+		log.FromContext(ctx).Info("Upgrading cluster",
+			"version", result.Version,
+			"runningVersion", result.RunningVersion,
+			"upgrade", result.Upgrade,
+		)
+		wasBlocked := meta.IsStatusConditionTrue(osc.Status.Conditions, corev1alpha1.ConditionUpgradeBlocked)
+		r.recordUpgradeEvent(osc, osc.Status.Upgrade, result.Upgrade, wasBlocked, result.Blocked)
+		osc.Status = *observed
+		if err := r.Status().Update(ctx, osc); err != nil {
+			return upgrade.Result{}, err
+		}
+	}
+
+	return result, nil
+}
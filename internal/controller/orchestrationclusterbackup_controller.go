@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+// backupFinalizer holds back the deletion of a running backup until exporting is resumed.
+const backupFinalizer = "core.camunda.io/backup-finalizer"
+
+// OrchestrationClusterBackupReconciler reconciles a OrchestrationClusterBackup object
+type OrchestrationClusterBackupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the credentials of the secondary storage without caching Secrets.
+	APIReader client.Reader
+	// HealthChecker reaches the management API of the cluster.
+	HealthChecker ClusterHealthChecker
+}
+
+// nolint:lll
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets;configmaps,verbs=get
+
+// Reconcile advances the backup by one step of the backup state machine and persists its status.
+func (r *OrchestrationClusterBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	clusterBackup := new(corev1alpha1.OrchestrationClusterBackup)
+	if err := r.Get(ctx, req.NamespacedName, clusterBackup); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := logf.FromContext(ctx, "cluster", clusterBackup.Spec.ClusterName, "phase", clusterBackup.Status.Phase)
+
+	if !clusterBackup.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalize(ctx, clusterBackup)
+	}
+	if backup.Done(clusterBackup.Status.Phase) {
+		return ctrl.Result{}, r.removeFinalizer(ctx, clusterBackup)
+	}
+
+	osc := new(corev1alpha1.OrchestrationCluster)
+	key := client.ObjectKey{Namespace: clusterBackup.Namespace, Name: clusterBackup.Spec.ClusterName}
+	if err := r.Get(ctx, key, osc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		status := clusterBackup.Status.DeepCopy()
+		status.Message = fmt.Sprintf("cluster %s not found", key.Name)
+		return ctrl.Result{RequeueAfter: backup.PollInterval}, r.updateStatus(ctx, clusterBackup, status)
+	}
+
+	if controllerutil.AddFinalizer(clusterBackup, backupFinalizer) {
+		if err := r.Update(ctx, clusterBackup); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	act, snaps, err := backupClients(ctx, r.HealthChecker, r.APIReader, osc)
+	if err != nil {
+		log.Error(err, "Failed to create backup clients")
+		return ctrl.Result{}, err
+	}
+
+	result, err := backup.Step(ctx, clusterBackup, osc, act, snaps, metav1.Now())
+	if err != nil {
+		log.Error(err, "Failed to back up cluster")
+		return ctrl.Result{}, err
+	}
+	if result.Status.Phase != clusterBackup.Status.Phase {
+		log.Info("Backup phase changed", "backupID", result.Status.BackupID, "newPhase", result.Status.Phase,
+			"message", result.Status.Message)
+	}
+
+	return ctrl.Result{RequeueAfter: result.RequeueAfter}, r.updateStatus(ctx, clusterBackup, &result.Status)
+}
+
+// finalize resumes exporting if the backup is deleted while it paused exporting, and removes the
+// finalizer. Nothing is left to resume once the cluster is gone.
+func (r *OrchestrationClusterBackupReconciler) finalize(
+	ctx context.Context,
+	clusterBackup *corev1alpha1.OrchestrationClusterBackup,
+) error {
+	if !controllerutil.ContainsFinalizer(clusterBackup, backupFinalizer) {
+		return nil
+	}
+
+	osc := new(corev1alpha1.OrchestrationCluster)
+	key := client.ObjectKey{Namespace: clusterBackup.Namespace, Name: clusterBackup.Spec.ClusterName}
+	err := r.Get(ctx, key, osc)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err == nil && osc.DeletionTimestamp.IsZero() {
+		act, err := r.HealthChecker.Actuator(ctx, osc)
+		if err != nil {
+			return err
+		}
+		if err := backup.Abort(ctx, clusterBackup.Status, act); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Aborted deleted backup", "backupID", clusterBackup.Status.BackupID)
+	}
+
+	return r.removeFinalizer(ctx, clusterBackup)
+}
+
+// removeFinalizer removes the finalizer once the backup no longer pauses exporting.
+func (r *OrchestrationClusterBackupReconciler) removeFinalizer(
+	ctx context.Context,
+	clusterBackup *corev1alpha1.OrchestrationClusterBackup,
+) error {
+	if !controllerutil.RemoveFinalizer(clusterBackup, backupFinalizer) {
+		return nil
+	}
+	return r.Update(ctx, clusterBackup)
+}
+
+// backupClients returns the clients for the management API and for the snapshot API of the
+// secondary storage. The snapshot client is nil for clusters without secondary storage.
+// The credentials of the secondary storage are read with reader.
+func backupClients(
+	ctx context.Context,
+	healthChecker ClusterHealthChecker,
+	reader client.Reader,
+	osc *corev1alpha1.OrchestrationCluster,
+) (backup.Actuator, backup.Snapshots, error) {
+	act, err := healthChecker.Actuator(ctx, osc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !backup.HasSecondaryStorage(osc.Spec) {
+		return act, nil, nil
+	}
+	snaps, err := newSnapshotClient(ctx, reader, osc.Namespace, osc.Spec.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	return act, snaps, nil
+}
+
+// updateStatus writes the status if it differs from the current status of the backup.
+func (r *OrchestrationClusterBackupReconciler) updateStatus(
+	ctx context.Context,
+	clusterBackup *corev1alpha1.OrchestrationClusterBackup,
+	status *corev1alpha1.OrchestrationClusterBackupStatus,
+) error {
+	if equality.Semantic.DeepEqual(clusterBackup.Status, *status) {
+		return nil
+	}
+	clusterBackup.Status = *status
+	return r.Status().Update(ctx, clusterBackup)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OrchestrationClusterBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.OrchestrationClusterBackup{}).
+		Named("orchestrationclusterbackup").
+		Complete(r)
+}
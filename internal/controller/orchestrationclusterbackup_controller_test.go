@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/actuator/actuatortest"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+var _ = Describe("OrchestrationClusterBackup Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-backup"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind OrchestrationClusterBackup")
+			resource := &corev1alpha1.OrchestrationClusterBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.OrchestrationClusterBackupSpec{
+					ClusterName: "missing-cluster",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &corev1alpha1.OrchestrationClusterBackup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance OrchestrationClusterBackup")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should wait for the cluster to exist", func() {
+			controllerReconciler := &OrchestrationClusterBackupReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(backup.PollInterval))
+
+			resource := &corev1alpha1.OrchestrationClusterBackup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Message).To(Equal("cluster missing-cluster not found"))
+		})
+	})
+
+	Context("When a running backup is deleted", func() {
+		const resourceName = "test-running-backup"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		It("should release the backup once nothing is left to resume", func() {
+			By("creating a backup that is backing up the brokers")
+			resource := &corev1alpha1.OrchestrationClusterBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  "default",
+					Finalizers: []string{backupFinalizer},
+				},
+				Spec: corev1alpha1.OrchestrationClusterBackupSpec{
+					ClusterName: "deleted-cluster",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.Phase = corev1alpha1.BackupPhaseBackingUpBrokers
+			resource.Status.BackupID = 42
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			By("deleting the backup")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			controllerReconciler := &OrchestrationClusterBackupReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &corev1alpha1.OrchestrationClusterBackup{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should resume exporting of the cluster", func() {
+			server := actuatortest.NewServer()
+			defer server.Close()
+			act := actuator.NewClient(server.BaseURL())
+			Expect(act.PauseExporting(ctx, true)).To(Succeed())
+
+			By("creating the cluster that is backed up")
+			cluster := &corev1alpha1.OrchestrationCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "backed-up-cluster", Namespace: "default"},
+				Spec: corev1alpha1.OrchestrationClusterSpec{
+					PartitionCount:    3,
+					ReplicationFactor: 3,
+					ClusterSize:       3,
+					Database:          corev1alpha1.Database{Type: corev1alpha1.PostgresqlDatabaseType},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+			}()
+
+			By("creating a backup that is backing up the brokers")
+			resource := &corev1alpha1.OrchestrationClusterBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  "default",
+					Finalizers: []string{backupFinalizer},
+				},
+				Spec: corev1alpha1.OrchestrationClusterBackupSpec{
+					ClusterName: cluster.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.Phase = corev1alpha1.BackupPhaseBackingUpBrokers
+			resource.Status.BackupID = 43
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			By("deleting the backup")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			controllerReconciler := &OrchestrationClusterBackupReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				APIReader:     k8sClient,
+				HealthChecker: &fakeHealthChecker{actuator: act},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(server.Exporting()).To(Equal(actuatortest.ExportingRunning))
+			err = k8sClient.Get(ctx, typeNamespacedName, &corev1alpha1.OrchestrationClusterBackup{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+// OrchestrationClusterBackupScheduleReconciler reconciles a OrchestrationClusterBackupSchedule object
+type OrchestrationClusterBackupScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// APIReader reads the credentials of the secondary storage without caching Secrets.
+	APIReader client.Reader
+	// HealthChecker reaches the management API of the cluster.
+	HealthChecker ClusterHealthChecker
+}
+
+// nolint:lll
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackupschedules/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterbackups,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile creates the backups that are due, prunes the backups outside of the retention policy
+// and records the outcome of the backups in the status of the schedule.
+func (r *OrchestrationClusterBackupScheduleReconciler) Reconcile(
+	ctx context.Context,
+	req ctrl.Request,
+) (ctrl.Result, error) {
+	schedule := new(corev1alpha1.OrchestrationClusterBackupSchedule)
+	if err := r.Get(ctx, req.NamespacedName, schedule); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log := logf.FromContext(ctx, "cluster", schedule.Spec.ClusterName)
+
+	backups := new(corev1alpha1.OrchestrationClusterBackupList)
+	if err := r.List(ctx, backups, client.InNamespace(schedule.Namespace),
+		client.MatchingLabels{backup.ScheduleLabel: schedule.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err := backup.EvaluateSchedule(schedule, backups.Items, time.Now())
+	if err != nil {
+		// The schedule is evaluated again once its spec is fixed.
+		log.Error(err, "Invalid backup schedule")
+		r.Recorder.Event(schedule, corev1.EventTypeWarning, corev1alpha1.ReasonInvalidSchedule, err.Error())
+		status := schedule.Status.DeepCopy()
+		setScheduledCondition(&status.Conditions, schedule.Generation, err)
+		return ctrl.Result{}, r.updateStatus(ctx, schedule, status)
+	}
+	setScheduledCondition(&result.Status.Conditions, schedule.Generation, nil)
+
+	for _, failed := range result.Failed {
+		r.Recorder.Eventf(schedule, corev1.EventTypeWarning, "BackupFailed",
+			"Backup %s failed: %s", failed.Name, failed.Status.Message)
+	}
+
+	if err := r.prune(ctx, schedule, result.Prune); err != nil {
+		log.Error(err, "Failed to prune backups")
+		r.Recorder.Event(schedule, corev1.EventTypeWarning, "PruneFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if result.Create != nil {
+		if err := controllerutil.SetControllerReference(schedule, result.Create, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, result.Create); err != nil && !apierrors.IsAlreadyExists(err) {
+			log.Error(err, "Failed to create scheduled backup")
+			r.Recorder.Eventf(schedule, corev1.EventTypeWarning, "BackupCreationFailed",
+				"Failed to create backup %s: %v", result.Create.Name, err)
+			return ctrl.Result{}, err
+		}
+		log.Info("Created scheduled backup", "backup", result.Create.Name)
+		r.Recorder.Eventf(schedule, corev1.EventTypeNormal, "BackupCreated", "Created backup %s", result.Create.Name)
+	}
+
+	return ctrl.Result{RequeueAfter: result.RequeueAfter}, r.updateStatus(ctx, schedule, &result.Status)
+}
+
+// prune deletes the backups from the backup store of the cluster and then their resources. Backups
+// are only pruned while the cluster exists, as its management API deletes them from the store.
+func (r *OrchestrationClusterBackupScheduleReconciler) prune(
+	ctx context.Context,
+	schedule *corev1alpha1.OrchestrationClusterBackupSchedule,
+	backups []*corev1alpha1.OrchestrationClusterBackup,
+) error {
+	if len(backups) == 0 {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+
+	osc := new(corev1alpha1.OrchestrationCluster)
+	key := client.ObjectKey{Namespace: schedule.Namespace, Name: schedule.Spec.ClusterName}
+	if err := r.Get(ctx, key, osc); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Not pruning backups of missing cluster")
+			return nil
+		}
+		return err
+	}
+
+	var act backup.Actuator
+	var snaps backup.Snapshots
+	if osc.Spec.Backup != nil {
+		var err error
+		if act, snaps, err = backupClients(ctx, r.HealthChecker, r.APIReader, osc); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range backups {
+		if act != nil && b.Status.BackupID != 0 {
+			err := backup.Delete(ctx, act, snaps, osc.Spec.Backup.RepositoryName, b.Status.BackupID)
+			if err != nil {
+				return err
+			}
+		}
+		if err := r.Delete(ctx, b); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("Pruned backup", "backup", b.Name, "backupID", b.Status.BackupID)
+		r.Recorder.Eventf(schedule, corev1.EventTypeNormal, "BackupPruned", "Pruned backup %s", b.Name)
+	}
+	return nil
+}
+
+// setScheduledCondition sets the Ready condition after evaluating the schedule ended with the error.
+func setScheduledCondition(conditions *[]metav1.Condition, generation int64, err error) {
+	condition := metav1.Condition{
+		Type:               corev1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             corev1alpha1.ReasonScheduled,
+		Message:            "backups are scheduled",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = corev1alpha1.ReasonInvalidSchedule
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// updateStatus writes the status if it differs from the current status of the schedule.
+func (r *OrchestrationClusterBackupScheduleReconciler) updateStatus(
+	ctx context.Context,
+	schedule *corev1alpha1.OrchestrationClusterBackupSchedule,
+	status *corev1alpha1.OrchestrationClusterBackupScheduleStatus,
+) error {
+	if equality.Semantic.DeepEqual(schedule.Status, *status) {
+		return nil
+	}
+	schedule.Status = *status
+	return r.Status().Update(ctx, schedule)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OrchestrationClusterBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.OrchestrationClusterBackupSchedule{}).
+		Owns(&corev1alpha1.OrchestrationClusterBackup{}).
+		Named("orchestrationclusterbackupschedule").
+		Complete(r)
+}
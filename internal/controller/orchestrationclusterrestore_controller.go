@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+// OrchestrationClusterRestoreReconciler reconciles a OrchestrationClusterRestore object
+type OrchestrationClusterRestoreReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the credentials of the secondary storage without caching Secrets.
+	APIReader client.Reader
+}
+
+// nolint:lll
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.camunda.io,resources=orchestrationclusterrestores/finalizers,verbs=update
+
+// Reconcile advances the restore by one step of the restore state machine, creates or updates the
+// restored cluster and persists the status of the restore.
+func (r *OrchestrationClusterRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	restore := new(corev1alpha1.OrchestrationClusterRestore)
+	if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if backup.RestoreDone(restore.Status.Phase) {
+		return ctrl.Result{}, nil
+	}
+
+	log := logf.FromContext(ctx, "cluster", restore.Spec.Cluster.Name, "phase", restore.Status.Phase)
+
+	osc := new(corev1alpha1.OrchestrationCluster)
+	key := client.ObjectKey{Namespace: restore.Namespace, Name: restore.Spec.Cluster.Name}
+	if err := r.Get(ctx, key, osc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		osc = nil
+	}
+
+	var snaps backup.Snapshots
+	if backup.HasSecondaryStorage(restore.Spec.Cluster.Spec) {
+		snapshotClient, err := newSnapshotClient(ctx, r.APIReader, restore.Namespace, restore.Spec.Cluster.Spec.Database)
+		if err != nil {
+			log.Error(err, "Failed to create snapshot client")
+			return ctrl.Result{}, err
+		}
+		snaps = snapshotClient
+	}
+
+	result, err := backup.RestoreStep(ctx, restore, osc, snaps, metav1.Now())
+	if err != nil {
+		log.Error(err, "Failed to restore cluster", "backupID", restore.Spec.BackupID)
+		return ctrl.Result{}, err
+	}
+
+	if result.Cluster != nil {
+		if osc == nil {
+			log.Info("Creating cluster from backup", "backupID", restore.Spec.BackupID)
+			err = r.Create(ctx, result.Cluster)
+		} else {
+			err = r.Update(ctx, result.Cluster)
+		}
+		if err != nil {
+			log.Error(err, "Failed to apply restored cluster")
+			return ctrl.Result{}, err
+		}
+	}
+	if result.Status.Phase != restore.Status.Phase {
+		log.Info("Restore phase changed", "backupID", restore.Spec.BackupID, "newPhase", result.Status.Phase,
+			"message", result.Status.Message)
+	}
+
+	return ctrl.Result{RequeueAfter: result.RequeueAfter}, r.updateStatus(ctx, restore, &result.Status)
+}
+
+// updateStatus writes the status if it differs from the current status of the restore.
+func (r *OrchestrationClusterRestoreReconciler) updateStatus(
+	ctx context.Context,
+	restore *corev1alpha1.OrchestrationClusterRestore,
+	status *corev1alpha1.OrchestrationClusterRestoreStatus,
+) error {
+	if equality.Semantic.DeepEqual(restore.Status, *status) {
+		return nil
+	}
+	restore.Status = *status
+	return r.Status().Update(ctx, restore)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OrchestrationClusterRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.OrchestrationClusterRestore{}).
+		Named("orchestrationclusterrestore").
+		Complete(r)
+}
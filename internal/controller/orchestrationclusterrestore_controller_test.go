@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+var _ = Describe("OrchestrationClusterRestore Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-restore"
+		const clusterName = "restored-cluster"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		clusterNamespacedName := types.NamespacedName{
+			Name:      clusterName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind OrchestrationClusterRestore")
+			resource := &corev1alpha1.OrchestrationClusterRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.OrchestrationClusterRestoreSpec{
+					BackupID: 42,
+					Cluster: corev1alpha1.ClusterTemplate{
+						Name: clusterName,
+						Spec: corev1alpha1.OrchestrationClusterSpec{
+							Version:           "8.8.0",
+							PartitionCount:    3,
+							ReplicationFactor: 3,
+							ClusterSize:       3,
+							Database: corev1alpha1.Database{
+								Type:     corev1alpha1.PostgresqlDatabaseType,
+								HostName: "postgresql:5432",
+								UserName: "camunda",
+								Password: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql"},
+									Key:                  "password",
+								},
+							},
+							Backup: &corev1alpha1.BackupStore{
+								S3: &corev1alpha1.S3BackupStore{BucketName: "camunda-backups"},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &corev1alpha1.OrchestrationClusterRestore{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance OrchestrationClusterRestore")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			cluster := &corev1alpha1.OrchestrationCluster{}
+			if err := k8sClient.Get(ctx, clusterNamespacedName, cluster); err == nil {
+				Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+			}
+		})
+
+		It("should create the cluster restoring from the backup", func() {
+			controllerReconciler := &OrchestrationClusterRestoreReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
+			}
+
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			cluster := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, clusterNamespacedName, cluster)).To(Succeed())
+			Expect(cluster.Annotations).To(HaveKeyWithValue(backup.RestoreAnnotation, "42"))
+
+			resource := &corev1alpha1.OrchestrationClusterRestore{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(corev1alpha1.RestorePhaseRestoringBrokers))
+		})
+	})
+})
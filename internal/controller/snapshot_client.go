@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/snapshot"
+)
+
+// newSnapshotClient returns a client for the snapshot API of the secondary storage of a cluster,
+// authenticated with the credentials and certificates the cluster uses. The credentials are read
+// with reader, which bypasses the cache so the operator does not need to list and watch Secrets.
+func newSnapshotClient(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	database corev1alpha1.Database,
+) (*snapshot.Client, error) {
+	hostName := database.HostName
+	if !strings.Contains(hostName, "://") {
+		hostName = "http://" + hostName
+	}
+	baseURL, err := url.Parse(hostName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database host name %s: %w", database.HostName, err)
+	}
+
+	var opts []snapshot.Option
+	if database.Password.Name != "" {
+		password, err := secretValue(ctx, reader, namespace, database.Password.Name, database.Password.Key)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, snapshot.WithBasicAuth(database.UserName, string(password)))
+	}
+	if database.TLS != nil {
+		config, err := databaseTLSConfig(ctx, reader, namespace, database.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, snapshot.WithTLSConfig(config))
+	}
+
+	return snapshot.NewClient(*baseURL, opts...), nil
+}
+
+func databaseTLSConfig(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	databaseTLS *corev1alpha1.DatabaseTLS,
+) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if ca := databaseTLS.CA; ca != nil {
+		var pem []byte
+		var err error
+		switch {
+		case ca.SecretKeyRef != nil:
+			pem, err = secretValue(ctx, reader, namespace, ca.SecretKeyRef.Name, ca.SecretKeyRef.Key)
+		case ca.ConfigMapKeyRef != nil:
+			pem, err = configMapValue(ctx, reader, namespace, ca.ConfigMapKeyRef.Name, ca.ConfigMapKeyRef.Key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in the database ca")
+		}
+	}
+
+	if cert := databaseTLS.ClientCertificate; cert != nil {
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cert.Name}, secret); err != nil {
+			return nil, err
+		}
+		certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in secret %s: %w", cert.Name, err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+func secretValue(ctx context.Context, reader client.Reader, namespace, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s", key, name)
+	}
+	return value, nil
+}
+
+func configMapValue(ctx context.Context, reader client.Reader, namespace, name, key string) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap); err != nil {
+		return nil, err
+	}
+	value, ok := configMap.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in config map %s", key, name)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// Phases the clusters are counted by, derived from their conditions.
+const (
+	PhaseDeleting    = "Deleting"
+	PhaseReady       = "Ready"
+	PhaseDegraded    = "Degraded"
+	PhaseProgressing = "Progressing"
+	PhasePending     = "Pending"
+)
+
+// listTimeout bounds the time a scrape waits for the clusters to be listed.
+const listTimeout = 5 * time.Second
+
+var (
+	clustersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "clusters"),
+		"Number of managed clusters by phase and running version.",
+		[]string{"phase", "version"}, nil,
+	)
+	desiredBrokersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "brokers_desired"),
+		"Number of brokers the cluster is configured with.",
+		[]string{"namespace", "cluster"}, nil,
+	)
+	observedBrokersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "brokers_observed"),
+		"Number of brokers that are part of the cluster topology.",
+		[]string{"namespace", "cluster"}, nil,
+	)
+	pendingChangesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "pending_topology_changes"),
+		"Number of pending topology change operations of the cluster.",
+		[]string{"namespace", "cluster"}, nil,
+	)
+)
+
+// ClusterCollector exports the state of the managed clusters. It reads the clusters from the
+// cache on every scrape, so the series of deleted clusters disappear with them.
+type ClusterCollector struct {
+	reader client.Reader
+}
+
+// NewClusterCollector returns a collector reading the clusters with the reader, usually the
+// cached client of the manager.
+func NewClusterCollector(reader client.Reader) *ClusterCollector {
+	return &ClusterCollector{reader: reader}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clustersDesc
+	ch <- desiredBrokersDesc
+	ch <- observedBrokersDesc
+	ch <- pendingChangesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	var list corev1alpha1.OrchestrationClusterList
+	if err := c.reader.List(ctx, &list); err != nil {
+		logf.Log.WithName("metrics").Error(err, "Failed to list clusters")
+		ch <- prometheus.NewInvalidMetric(clustersDesc, err)
+		return
+	}
+
+	type phaseVersion struct{ phase, version string }
+	counts := map[phaseVersion]int{}
+	for i := range list.Items {
+		osc := &list.Items[i]
+		counts[phaseVersion{Phase(osc), runningVersion(osc)}]++
+
+		ch <- prometheus.MustNewConstMetric(desiredBrokersDesc, prometheus.GaugeValue,
+			float64(osc.Spec.ClusterSize), osc.Namespace, osc.Name)
+		ch <- prometheus.MustNewConstMetric(observedBrokersDesc, prometheus.GaugeValue,
+			float64(osc.Status.ObservedClusterSize), osc.Namespace, osc.Name)
+		ch <- prometheus.MustNewConstMetric(pendingChangesDesc, prometheus.GaugeValue,
+			float64(osc.Status.PendingChanges), osc.Namespace, osc.Name)
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(clustersDesc, prometheus.GaugeValue, float64(count), key.phase, key.version)
+	}
+}
+
+// Phase summarizes the conditions of the cluster.
+func Phase(osc *corev1alpha1.OrchestrationCluster) string {
+	switch {
+	case !osc.DeletionTimestamp.IsZero():
+		return PhaseDeleting
+	case meta.IsStatusConditionTrue(osc.Status.Conditions, corev1alpha1.ConditionReady):
+		return PhaseReady
+	case meta.IsStatusConditionTrue(osc.Status.Conditions, corev1alpha1.ConditionDegraded):
+		return PhaseDegraded
+	case meta.IsStatusConditionTrue(osc.Status.Conditions, corev1alpha1.ConditionProgressing):
+		return PhaseProgressing
+	}
+	return PhasePending
+}
+
+// runningVersion returns the version all brokers run, or the configured version until it is known.
+func runningVersion(osc *corev1alpha1.OrchestrationCluster) string {
+	if osc.Status.Version != "" {
+		return osc.Status.Version
+	}
+	return osc.Spec.Version
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func cluster(name, version string, ready bool) *corev1alpha1.OrchestrationCluster {
+	osc := &corev1alpha1.OrchestrationCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "camunda"},
+		Spec:       corev1alpha1.OrchestrationClusterSpec{Version: version, ClusterSize: 3},
+	}
+	if ready {
+		osc.Status = corev1alpha1.OrchestrationClusterStatus{
+			Version:             version,
+			ObservedClusterSize: 3,
+			Conditions: []metav1.Condition{
+				{Type: corev1alpha1.ConditionReady, Status: metav1.ConditionTrue},
+			},
+		}
+	} else {
+		osc.Status = corev1alpha1.OrchestrationClusterStatus{
+			ObservedClusterSize: 2,
+			PendingChanges:      1,
+			Conditions: []metav1.Condition{
+				{Type: corev1alpha1.ConditionReady, Status: metav1.ConditionFalse},
+				{Type: corev1alpha1.ConditionProgressing, Status: metav1.ConditionTrue},
+			},
+		}
+	}
+	return osc
+}
+
+func TestClusterCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1alpha1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		cluster("a", "8.7.0", true),
+		cluster("b", "8.7.0", true),
+		cluster("c", "8.8.0", false),
+	).Build()
+
+	expected := `
+# HELP camunda_operator_clusters Number of managed clusters by phase and running version.
+# TYPE camunda_operator_clusters gauge
+camunda_operator_clusters{phase="Progressing",version="8.8.0"} 1
+camunda_operator_clusters{phase="Ready",version="8.7.0"} 2
+# HELP camunda_operator_cluster_brokers_observed Number of brokers that are part of the cluster topology.
+# TYPE camunda_operator_cluster_brokers_observed gauge
+camunda_operator_cluster_brokers_observed{cluster="a",namespace="camunda"} 3
+camunda_operator_cluster_brokers_observed{cluster="b",namespace="camunda"} 3
+camunda_operator_cluster_brokers_observed{cluster="c",namespace="camunda"} 2
+# HELP camunda_operator_cluster_pending_topology_changes Number of pending topology change operations of the cluster.
+# TYPE camunda_operator_cluster_pending_topology_changes gauge
+camunda_operator_cluster_pending_topology_changes{cluster="a",namespace="camunda"} 0
+camunda_operator_cluster_pending_topology_changes{cluster="b",namespace="camunda"} 0
+camunda_operator_cluster_pending_topology_changes{cluster="c",namespace="camunda"} 1
+`
+	err := testutil.CollectAndCompare(NewClusterCollector(reader), strings.NewReader(expected),
+		"camunda_operator_clusters",
+		"camunda_operator_cluster_brokers_observed",
+		"camunda_operator_cluster_pending_topology_changes",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, testutil.CollectAndCount(NewClusterCollector(reader),
+		"camunda_operator_cluster_brokers_desired",
+		"camunda_operator_cluster_brokers_observed",
+		"camunda_operator_cluster_pending_topology_changes",
+	))
+}
+
+func TestPhase(t *testing.T) {
+	deleting := cluster("a", "8.7.0", true)
+	deleting.DeletionTimestamp = ptr.To(metav1.Now())
+	degraded := cluster("b", "8.7.0", false)
+	degraded.Status.Conditions = append(degraded.Status.Conditions,
+		metav1.Condition{Type: corev1alpha1.ConditionDegraded, Status: metav1.ConditionTrue})
+
+	assert.Equal(t, PhaseReady, Phase(cluster("a", "8.7.0", true)))
+	assert.Equal(t, PhaseProgressing, Phase(cluster("a", "8.7.0", false)))
+	assert.Equal(t, PhaseDegraded, Phase(degraded))
+	assert.Equal(t, PhaseDeleting, Phase(deleting))
+	assert.Equal(t, PhasePending, Phase(&corev1alpha1.OrchestrationCluster{}))
+}
+
+func TestObserveActuatorCall(t *testing.T) {
+	before := testutil.ToFloat64(actuatorErrors.WithLabelValues(OperationTopology))
+	ObserveActuatorCall(OperationTopology, time.Now(), errors.New("connection refused"))
+	ObserveActuatorCall(OperationTopology, time.Now(), nil)
+	assert.Equal(t, before+1, testutil.ToFloat64(actuatorErrors.WithLabelValues(OperationTopology)))
+}
@@ -0,0 +1,62 @@
+// Package metrics exports Prometheus metrics about the clusters the operator manages. They are
+// served on the metrics endpoint of the manager next to the controller-runtime metrics, so
+// dashboards and alerts do not need to scrape the brokers.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "camunda_operator"
+
+// Operations of the management API whose calls are measured.
+const (
+	OperationTopology = "topology"
+)
+
+var (
+	lastHealthCheck = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_last_health_check_success_timestamp_seconds",
+		Help:      "Unix time of the last health check of the cluster that fetched its topology.",
+	}, []string{"namespace", "cluster"})
+
+	actuatorDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "actuator_request_duration_seconds",
+		Help:      "Duration of the requests to the management API of the clusters.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	actuatorErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "actuator_request_errors_total",
+		Help:      "Number of failed requests to the management API of the clusters.",
+	}, []string{"operation"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(lastHealthCheck, actuatorDuration, actuatorErrors)
+}
+
+// ObserveActuatorCall records the duration of a call to the management API that started at start,
+// and counts it as failed if err is not nil.
+func ObserveActuatorCall(operation string, start time.Time, err error) {
+	actuatorDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		actuatorErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// RecordHealthCheck records a successful health check of the cluster at now.
+func RecordHealthCheck(namespace, cluster string, now time.Time) {
+	lastHealthCheck.WithLabelValues(namespace, cluster).Set(float64(now.Unix()))
+}
+
+// ForgetCluster removes the series of a deleted cluster.
+func ForgetCluster(namespace, cluster string) {
+	lastHealthCheck.DeleteLabelValues(namespace, cluster)
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/bundles"
+	"github.com/camunda/camunda-operator/pkg/storage"
+)
+
+const (
+	defaultClusterSize       int32 = 3
+	defaultPartitionCount    int32 = 3
+	defaultReplicationFactor int32 = 3
+
+	// authenticationConstraint is the range of versions that support configuring the authentication method.
+	authenticationConstraint = ">= 8.8.0-0"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var orchestrationclusterlog = logf.Log.WithName("orchestrationcluster-resource")
+
+// SetupOrchestrationClusterWebhookWithManager registers the webhook for OrchestrationCluster in the manager.
+func SetupOrchestrationClusterWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1alpha1.OrchestrationCluster{}).
+		WithValidator(&OrchestrationClusterCustomValidator{}).
+		WithDefaulter(&OrchestrationClusterCustomDefaulter{}).
+		Complete()
+}
+
+// nolint:lll
+// +kubebuilder:webhook:path=/mutate-core-camunda-io-v1alpha1-orchestrationcluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.camunda.io,resources=orchestrationclusters,verbs=create;update,versions=v1alpha1,name=morchestrationcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OrchestrationClusterCustomDefaulter sets default values on the OrchestrationCluster
+// when it is created or updated.
+type OrchestrationClusterCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &OrchestrationClusterCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind OrchestrationCluster.
+func (d *OrchestrationClusterCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	orchestrationcluster, ok := obj.(*corev1alpha1.OrchestrationCluster)
+	if !ok {
+		return fmt.Errorf("expected an OrchestrationCluster object but got %T", obj)
+	}
+	orchestrationclusterlog.Info("Defaulting for OrchestrationCluster", "name", orchestrationcluster.GetName())
+
+	setDefaults(&orchestrationcluster.Spec)
+	return nil
+}
+
+// setDefaults sets the defaults of the fields that are not set.
+func setDefaults(spec *corev1alpha1.OrchestrationClusterSpec) {
+	if spec.Version == "" {
+		spec.Version = bundles.DefaultVersion
+	}
+	if spec.ClusterSize == 0 {
+		spec.ClusterSize = defaultClusterSize
+	}
+	if spec.PartitionCount == 0 {
+		spec.PartitionCount = defaultPartitionCount
+	}
+	if spec.ReplicationFactor == 0 {
+		spec.ReplicationFactor = min(defaultReplicationFactor, spec.ClusterSize)
+	}
+}
+
+// nolint:lll
+// +kubebuilder:webhook:path=/validate-core-camunda-io-v1alpha1-orchestrationcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.camunda.io,resources=orchestrationclusters,verbs=create;update,versions=v1alpha1,name=vorchestrationcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OrchestrationClusterCustomValidator validates the OrchestrationCluster when it is created or updated.
+type OrchestrationClusterCustomValidator struct{}
+
+var _ webhook.CustomValidator = &OrchestrationClusterCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type OrchestrationCluster.
+func (v *OrchestrationClusterCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	orchestrationcluster, ok := obj.(*corev1alpha1.OrchestrationCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a OrchestrationCluster object but got %T", obj)
+	}
+	orchestrationclusterlog.Info("Validation for OrchestrationCluster upon creation", "name", orchestrationcluster.GetName())
+
+	return nil, toInvalid(orchestrationcluster, validateSpec(orchestrationcluster))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type OrchestrationCluster.
+func (v *OrchestrationClusterCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	orchestrationcluster, ok := newObj.(*corev1alpha1.OrchestrationCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a OrchestrationCluster object for the newObj but got %T", newObj)
+	}
+	oldOrchestrationcluster, ok := oldObj.(*corev1alpha1.OrchestrationCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a OrchestrationCluster object for the oldObj but got %T", oldObj)
+	}
+	orchestrationclusterlog.Info("Validation for OrchestrationCluster upon update", "name", orchestrationcluster.GetName())
+
+	// A deleted cluster only waits for its finalizer to be removed, which must not be blocked.
+	if orchestrationcluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	allErrs := validateSpec(orchestrationcluster)
+	allErrs = append(allErrs, validateImmutableFields(oldOrchestrationcluster, orchestrationcluster)...)
+	return nil, toInvalid(orchestrationcluster, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type OrchestrationCluster.
+func (v *OrchestrationClusterCustomValidator) ValidateDelete(
+	_ context.Context,
+	_ runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateSpec(osc *corev1alpha1.OrchestrationCluster) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if _, err := semver.NewVersion(osc.Spec.Version); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("version"), osc.Spec.Version,
+			"must be a valid semantic version"))
+	}
+	if osc.Spec.ClusterSize < 1 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("clusterSize"), osc.Spec.ClusterSize,
+			"must be at least 1"))
+	}
+	if osc.Spec.PartitionCount < 1 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("partitionCount"), osc.Spec.PartitionCount,
+			"must be at least 1"))
+	}
+	if osc.Spec.ReplicationFactor < 1 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("replicationFactor"), osc.Spec.ReplicationFactor,
+			"must be at least 1"))
+	}
+	if osc.Spec.ReplicationFactor > osc.Spec.ClusterSize {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("replicationFactor"), osc.Spec.ReplicationFactor,
+			fmt.Sprintf("must not be greater than clusterSize (%d)", osc.Spec.ClusterSize)))
+	}
+
+	allErrs = append(allErrs, validateDatabase(osc.Spec.Database, specPath.Child("database"))...)
+	allErrs = append(allErrs, validateAuthentication(osc.Spec, specPath.Child("authentication"))...)
+	return append(allErrs, validateBackup(osc.Spec, specPath.Child("backup"))...)
+}
+
+func validateBackup(spec corev1alpha1.OrchestrationClusterSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.Backup == nil || spec.Database.Type == corev1alpha1.PostgresqlDatabaseType {
+		return allErrs
+	}
+
+	if spec.Backup.RepositoryName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("repositoryName"),
+			"is required to back up the secondary storage"))
+	}
+	// The operator snapshots and restores the indices itself and only authenticates with a password.
+	if spec.Database.AWS != nil {
+		allErrs = append(allErrs, field.Forbidden(path,
+			"backups are not supported with AWS IAM authentication to the database"))
+	}
+	return allErrs
+}
+
+func validateAuthentication(spec corev1alpha1.OrchestrationClusterSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	authentication := spec.Authentication
+	if authentication == nil || (authentication.Basic == nil && authentication.OIDC == nil) {
+		return allErrs
+	}
+
+	// An invalid version is already reported by validateSpec.
+	if version, err := semver.NewVersion(spec.Version); err == nil {
+		constraint, err := semver.NewConstraint(authenticationConstraint)
+		if err != nil {
+			return append(allErrs, field.InternalError(path, err))
+		}
+		if !constraint.Check(version) {
+			allErrs = append(allErrs, field.Forbidden(path,
+				fmt.Sprintf("requires version %s, got %s", authenticationConstraint, spec.Version)))
+		}
+	}
+
+	if basic := authentication.Basic; basic != nil && basic.AdminSecret.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("basic", "adminSecret", "name"),
+			"is required to create the initial user"))
+	}
+	if oidc := authentication.OIDC; oidc != nil {
+		oidcPath := path.Child("oidc")
+		if oidc.IssuerURL == "" {
+			allErrs = append(allErrs, field.Required(oidcPath.Child("issuerURL"), "is required for oidc"))
+		}
+		if oidc.ClientID == "" {
+			allErrs = append(allErrs, field.Required(oidcPath.Child("clientID"), "is required for oidc"))
+		}
+		if oidc.ClientSecret.Name == "" {
+			allErrs = append(allErrs, field.Required(oidcPath.Child("clientSecret", "name"), "is required for oidc"))
+		}
+		if oidc.ClientSecret.Key == "" {
+			allErrs = append(allErrs, field.Required(oidcPath.Child("clientSecret", "key"), "is required for oidc"))
+		}
+	}
+	return allErrs
+}
+
+func validateDatabase(database corev1alpha1.Database, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	required := fmt.Sprintf("is required for %s", database.Type)
+	if database.HostName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("hostName"), required))
+	}
+	// OpenSearch on AWS authenticates with IAM, the password is optional then.
+	awsAuthenticated := database.Type == corev1alpha1.OpensearchDatabaseType && database.AWS != nil
+	if !awsAuthenticated || database.Password.Name != "" {
+		if database.Password.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("password", "name"), required))
+		}
+		if database.Password.Key == "" {
+			allErrs = append(allErrs, field.Required(path.Child("password", "key"), required))
+		}
+	}
+	if database.TLS != nil {
+		allErrs = append(allErrs, validateDatabaseTLS(database, path.Child("tls"))...)
+	}
+	if database.AWS != nil && database.Type != corev1alpha1.OpensearchDatabaseType {
+		allErrs = append(allErrs, field.Forbidden(path.Child("aws"),
+			fmt.Sprintf("is only supported for %s", corev1alpha1.OpensearchDatabaseType)))
+	}
+	if database.Type == corev1alpha1.PostgresqlDatabaseType && database.UserName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("userName"), required))
+	}
+
+	return allErrs
+}
+
+func validateDatabaseTLS(database corev1alpha1.Database, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	tls := database.TLS
+
+	if tls.CA == nil && tls.ClientCertificate == nil {
+		allErrs = append(allErrs, field.Required(path, "must reference a ca or a clientCertificate"))
+	}
+	if tls.CA != nil && (tls.CA.SecretKeyRef == nil) == (tls.CA.ConfigMapKeyRef == nil) {
+		allErrs = append(allErrs, field.Invalid(path.Child("ca"), tls.CA,
+			"exactly one of secretKeyRef and configMapKeyRef must be set"))
+	}
+	if tls.ClientCertificate != nil && database.Type == corev1alpha1.PostgresqlDatabaseType {
+		allErrs = append(allErrs, field.Forbidden(path.Child("clientCertificate"),
+			fmt.Sprintf("is not supported for %s", database.Type)))
+	}
+
+	return allErrs
+}
+
+// validateImmutableFields rejects changes to fields that cannot be changed once the cluster is created.
+// The old cluster is compared with its defaults applied, because clusters stored before a default
+// was introduced get it set by the defaulter on their next update.
+func validateImmutableFields(oldOsc, newOsc *corev1alpha1.OrchestrationCluster) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	oldOsc = oldOsc.DeepCopy()
+	setDefaults(&oldOsc.Spec)
+
+	if oldOsc.Spec.PartitionCount != newOsc.Spec.PartitionCount {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("partitionCount"),
+			"is immutable once the cluster is created"))
+	}
+	if oldOsc.Spec.ReplicationFactor != newOsc.Spec.ReplicationFactor {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("replicationFactor"),
+			"is immutable once the cluster is created"))
+	}
+	if oldOsc.Spec.Database.Type != newOsc.Spec.Database.Type {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("database", "type"),
+			"is immutable once the cluster is created"))
+	}
+
+	return append(allErrs, validateStorageUpdate(oldOsc.Spec.Storage, newOsc.Spec.Storage, specPath.Child("storage"))...)
+}
+
+// validateStorageUpdate only allows the data volumes to grow, everything else is fixed by the
+// volume claim templates of the StatefulSet.
+func validateStorageUpdate(oldStorage, newStorage *corev1alpha1.Storage, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if oldStorage == nil {
+		oldStorage = &corev1alpha1.Storage{}
+	}
+	if newStorage == nil {
+		newStorage = &corev1alpha1.Storage{}
+	}
+
+	oldSize, newSize := storage.DefaultSize, storage.DefaultSize
+	if oldStorage.Size != nil {
+		oldSize = *oldStorage.Size
+	}
+	if newStorage.Size != nil {
+		newSize = *newStorage.Size
+	}
+	if newSize.Cmp(oldSize) < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("size"), newSize.String(),
+			fmt.Sprintf("must not be smaller than %s, volumes cannot be shrunk", oldSize.String())))
+	}
+	if !equality.Semantic.DeepEqual(oldStorage.StorageClassName, newStorage.StorageClassName) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("storageClassName"),
+			"is immutable once the cluster is created"))
+	}
+	if !equality.Semantic.DeepEqual(oldStorage.AccessModes, newStorage.AccessModes) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("accessModes"),
+			"is immutable once the cluster is created"))
+	}
+
+	return allErrs
+}
+
+func toInvalid(osc *corev1alpha1.OrchestrationCluster, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		corev1alpha1.GroupVersion.WithKind("OrchestrationCluster").GroupKind(),
+		osc.Name, allErrs)
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/bundles"
+)
+
+var _ = Describe("OrchestrationCluster Webhook", func() {
+	var (
+		obj       *corev1alpha1.OrchestrationCluster
+		oldObj    *corev1alpha1.OrchestrationCluster
+		validator OrchestrationClusterCustomValidator
+		defaulter OrchestrationClusterCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.OrchestrationCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "webhook-test",
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.OrchestrationClusterSpec{
+				Version:           "8.7.7",
+				ClusterSize:       3,
+				PartitionCount:    3,
+				ReplicationFactor: 3,
+				Database: corev1alpha1.Database{
+					Type:     corev1alpha1.ElasticsearchDatabaseType,
+					HostName: "elasticsearch",
+					UserName: "elastic",
+					Password: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "elasticsearch"},
+						Key:                  "password",
+					},
+				},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = OrchestrationClusterCustomValidator{}
+		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
+		defaulter = OrchestrationClusterCustomDefaulter{}
+		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
+	})
+
+	Context("When creating OrchestrationCluster under Defaulting Webhook", func() {
+		It("Should apply defaults when fields are not set", func() {
+			By("simulating a cluster without version and sizing")
+			obj.Spec.Version = ""
+			obj.Spec.ClusterSize = 0
+			obj.Spec.PartitionCount = 0
+			obj.Spec.ReplicationFactor = 0
+
+			By("calling the Default method to apply defaults")
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			By("checking that the default values are set")
+			Expect(obj.Spec.Version).To(Equal(bundles.DefaultVersion))
+			Expect(obj.Spec.ClusterSize).To(Equal(int32(3)))
+			Expect(obj.Spec.PartitionCount).To(Equal(int32(3)))
+			Expect(obj.Spec.ReplicationFactor).To(Equal(int32(3)))
+		})
+
+		It("Should not default the replication factor above the cluster size", func() {
+			obj.Spec.ClusterSize = 1
+			obj.Spec.ReplicationFactor = 0
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ReplicationFactor).To(Equal(int32(1)))
+		})
+
+		It("Should keep values that are set", func() {
+			obj.Spec.ClusterSize = 5
+			obj.Spec.PartitionCount = 6
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Version).To(Equal("8.7.7"))
+			Expect(obj.Spec.ClusterSize).To(Equal(int32(5)))
+			Expect(obj.Spec.PartitionCount).To(Equal(int32(6)))
+		})
+	})
+
+	Context("When creating or updating OrchestrationCluster under Validating Webhook", func() {
+		It("Should admit a valid cluster", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny creation if the replication factor exceeds the cluster size", func() {
+			obj.Spec.ReplicationFactor = 4
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.replicationFactor")))
+		})
+
+		It("Should deny creation without partitions", func() {
+			obj.Spec.PartitionCount = 0
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.partitionCount")))
+		})
+
+		It("Should deny creation with an invalid version", func() {
+			obj.Spec.Version = "latest"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.version")))
+		})
+
+		It("Should deny creation of elasticsearch without host and password", func() {
+			obj.Spec.Database.HostName = ""
+			obj.Spec.Database.Password = corev1.SecretKeySelector{}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.database.hostName")))
+			Expect(err).To(MatchError(ContainSubstring("spec.database.password.name")))
+			Expect(err).To(MatchError(ContainSubstring("spec.database.password.key")))
+		})
+
+		It("Should deny creation of postgresql without credentials", func() {
+			obj.Spec.Version = "8.8.0"
+			obj.Spec.Database = corev1alpha1.Database{
+				Type:     corev1alpha1.PostgresqlDatabaseType,
+				HostName: "postgresql:5432",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.database.userName")))
+			Expect(err).To(MatchError(ContainSubstring("spec.database.password.name")))
+		})
+
+		It("Should admit opensearch with AWS authentication but without password", func() {
+			obj.Spec.Database = corev1alpha1.Database{
+				Type:     corev1alpha1.OpensearchDatabaseType,
+				HostName: "https://search-camunda.eu-west-1.es.amazonaws.com",
+				AWS:      &corev1alpha1.AWSAuthentication{Region: "eu-west-1"},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny AWS authentication for elasticsearch", func() {
+			obj.Spec.Database.AWS = &corev1alpha1.AWSAuthentication{Region: "eu-west-1"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.database.aws")))
+		})
+
+		It("Should deny a CA referencing both a secret and a config map", func() {
+			obj.Spec.Database.TLS = &corev1alpha1.DatabaseTLS{CA: &corev1alpha1.CertificateSource{
+				SecretKeyRef:    &corev1.SecretKeySelector{Key: "ca.crt"},
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{Key: "ca.crt"},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.database.tls.ca")))
+		})
+
+		It("Should deny a backup store without snapshot repository", func() {
+			obj.Spec.Backup = &corev1alpha1.BackupStore{
+				S3: &corev1alpha1.S3BackupStore{BucketName: "camunda-backups"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.backup.repositoryName")))
+
+			obj.Spec.Backup.RepositoryName = "camunda-backups"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny authentication before version 8.8", func() {
+			obj.Spec.Authentication = &corev1alpha1.Authentication{
+				Basic: &corev1alpha1.BasicAuthentication{
+					AdminSecret: corev1.LocalObjectReference{Name: "camunda-admin"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.authentication: Forbidden")))
+
+			obj.Spec.Version = "8.8.0"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny basic authentication without admin secret", func() {
+			obj.Spec.Version = "8.8.0"
+			obj.Spec.Authentication = &corev1alpha1.Authentication{Basic: &corev1alpha1.BasicAuthentication{}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.authentication.basic.adminSecret.name")))
+		})
+
+		It("Should deny oidc without issuer and client credentials", func() {
+			obj.Spec.Version = "8.8.0"
+			obj.Spec.Authentication = &corev1alpha1.Authentication{OIDC: &corev1alpha1.OIDCAuthentication{}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.authentication.oidc.issuerURL")))
+			Expect(err).To(MatchError(ContainSubstring("spec.authentication.oidc.clientID")))
+			Expect(err).To(MatchError(ContainSubstring("spec.authentication.oidc.clientSecret.name")))
+			Expect(err).To(MatchError(ContainSubstring("spec.authentication.oidc.clientSecret.key")))
+
+			obj.Spec.Authentication.OIDC = &corev1alpha1.OIDCAuthentication{
+				IssuerURL: "https://keycloak.example.com/realms/camunda",
+				ClientID:  "orchestration",
+				ClientSecret: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "orchestration"},
+					Key:                  "client-secret",
+				},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit growing the data volumes", func() {
+			obj.Spec.Storage = &corev1alpha1.Storage{Size: ptr.To(resource.MustParse("20Gi"))}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny shrinking the data volumes or changing the storage class", func() {
+			obj.Spec.Storage = &corev1alpha1.Storage{
+				Size:             ptr.To(resource.MustParse("5Gi")),
+				StorageClassName: ptr.To("fast-ssd"),
+			}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.storage.size")))
+			Expect(err).To(MatchError(ContainSubstring("spec.storage.storageClassName")))
+		})
+
+		It("Should admit scaling and upgrading", func() {
+			obj.Spec.ClusterSize = 5
+			obj.Spec.Version = "8.8.0"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny changes to immutable fields", func() {
+			obj.Spec.PartitionCount = 6
+			obj.Spec.ReplicationFactor = 1
+			obj.Spec.Database.Type = corev1alpha1.PostgresqlDatabaseType
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.partitionCount")))
+			Expect(err).To(MatchError(ContainSubstring("spec.replicationFactor")))
+			Expect(err).To(MatchError(ContainSubstring("spec.database.type")))
+		})
+
+		It("Should admit updating a cluster stored without partition count and replication factor", func() {
+			By("simulating a cluster stored before the defaults were introduced")
+			oldObj.Spec.PartitionCount = 0
+			oldObj.Spec.ReplicationFactor = 0
+
+			By("defaulting the update like the mutating webhook does")
+			obj = oldObj.DeepCopy()
+			obj.Finalizers = []string{"core.camunda.io/finalizer"}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny changing a defaulted partition count of a cluster stored without it", func() {
+			oldObj.Spec.PartitionCount = 0
+			obj.Spec.PartitionCount = 6
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.partitionCount")))
+		})
+
+		It("Should admit removing the finalizer of a deleted cluster", func() {
+			oldObj.DeletionTimestamp = ptr.To(metav1.Now())
+			oldObj.Finalizers = []string{"core.camunda.io/finalizer"}
+			obj.DeletionTimestamp = oldObj.DeletionTimestamp
+			obj.Spec.PartitionCount = 6
+			obj.Spec.Database.HostName = ""
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When submitting OrchestrationCluster to the API server", func() {
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, obj)
+		})
+
+		It("Should default and admit a minimal cluster", func() {
+			obj.Spec.Version = ""
+			obj.Spec.ClusterSize = 0
+			obj.Spec.PartitionCount = 0
+			obj.Spec.ReplicationFactor = 0
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+
+			created := &corev1alpha1.OrchestrationCluster{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), created)).To(Succeed())
+			Expect(created.Spec.ClusterSize).To(Equal(int32(3)))
+			Expect(created.Spec.ReplicationFactor).To(Equal(int32(3)))
+		})
+
+		It("Should reject an invalid cluster", func() {
+			obj.Spec.ClusterSize = 1
+			obj.Spec.ReplicationFactor = 3
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err) || apierrors.IsForbidden(err)).To(BeTrue(), "unexpected error %v", err)
+		})
+
+		It("Should reject changing the partition count", func() {
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+
+			obj.Spec.PartitionCount = 6
+			err := k8sClient.Update(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.partitionCount")))
+		})
+	})
+})
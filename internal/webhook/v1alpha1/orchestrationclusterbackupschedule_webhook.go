@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var orchestrationclusterbackupschedulelog = logf.Log.WithName("orchestrationclusterbackupschedule-resource")
+
+// SetupOrchestrationClusterBackupScheduleWebhookWithManager registers the webhook for
+// OrchestrationClusterBackupSchedule in the manager.
+func SetupOrchestrationClusterBackupScheduleWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1alpha1.OrchestrationClusterBackupSchedule{}).
+		WithValidator(&OrchestrationClusterBackupScheduleCustomValidator{}).
+		Complete()
+}
+
+// nolint:lll
+// +kubebuilder:webhook:path=/validate-core-camunda-io-v1alpha1-orchestrationclusterbackupschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.camunda.io,resources=orchestrationclusterbackupschedules,verbs=create;update,versions=v1alpha1,name=vorchestrationclusterbackupschedule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OrchestrationClusterBackupScheduleCustomValidator validates the OrchestrationClusterBackupSchedule
+// when it is created or updated.
+type OrchestrationClusterBackupScheduleCustomValidator struct{}
+
+var _ webhook.CustomValidator = &OrchestrationClusterBackupScheduleCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+// OrchestrationClusterBackupSchedule.
+func (v *OrchestrationClusterBackupScheduleCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	schedule, ok := obj.(*corev1alpha1.OrchestrationClusterBackupSchedule)
+	if !ok {
+		return nil, fmt.Errorf("expected a OrchestrationClusterBackupSchedule object but got %T", obj)
+	}
+	orchestrationclusterbackupschedulelog.Info("Validation for OrchestrationClusterBackupSchedule upon creation",
+		"name", schedule.GetName())
+
+	return nil, toInvalidSchedule(schedule, validateScheduleSpec(schedule))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+// OrchestrationClusterBackupSchedule.
+func (v *OrchestrationClusterBackupScheduleCustomValidator) ValidateUpdate(
+	_ context.Context,
+	_, newObj runtime.Object,
+) (admission.Warnings, error) {
+	schedule, ok := newObj.(*corev1alpha1.OrchestrationClusterBackupSchedule)
+	if !ok {
+		return nil, fmt.Errorf("expected a OrchestrationClusterBackupSchedule object for the newObj but got %T", newObj)
+	}
+	orchestrationclusterbackupschedulelog.Info("Validation for OrchestrationClusterBackupSchedule upon update",
+		"name", schedule.GetName())
+
+	return nil, toInvalidSchedule(schedule, validateScheduleSpec(schedule))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+// OrchestrationClusterBackupSchedule.
+func (v *OrchestrationClusterBackupScheduleCustomValidator) ValidateDelete(
+	_ context.Context,
+	_ runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateScheduleSpec rejects schedules and time zones the controller cannot evaluate.
+func validateScheduleSpec(schedule *corev1alpha1.OrchestrationClusterBackupSchedule) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if _, err := backup.ParseSchedule(schedule.Spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("schedule"), schedule.Spec.Schedule, err.Error()))
+	}
+	if _, err := backup.Location(schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("timeZone"), *schedule.Spec.TimeZone, err.Error()))
+	}
+	return allErrs
+}
+
+func toInvalidSchedule(schedule *corev1alpha1.OrchestrationClusterBackupSchedule, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		corev1alpha1.GroupVersion.WithKind("OrchestrationClusterBackupSchedule").GroupKind(),
+		schedule.Name, allErrs)
+}
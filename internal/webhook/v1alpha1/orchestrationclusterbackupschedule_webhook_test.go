@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+var _ = Describe("OrchestrationClusterBackupSchedule Webhook", func() {
+	var (
+		obj       *corev1alpha1.OrchestrationClusterBackupSchedule
+		oldObj    *corev1alpha1.OrchestrationClusterBackupSchedule
+		validator OrchestrationClusterBackupScheduleCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.OrchestrationClusterBackupSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "webhook-test",
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.OrchestrationClusterBackupScheduleSpec{
+				ClusterName: "camunda",
+				Schedule:    "0 2 * * *",
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = OrchestrationClusterBackupScheduleCustomValidator{}
+	})
+
+	Context("When creating or updating OrchestrationClusterBackupSchedule under Validating Webhook", func() {
+		It("Should admit a valid schedule", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Schedule = "30 1 * JAN-JUN SAT,SUN"
+			obj.Spec.TimeZone = ptr.To("Europe/Berlin")
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid schedule", func() {
+			obj.Spec.Schedule = "every night"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.schedule")))
+		})
+
+		It("Should deny a time zone in the schedule", func() {
+			obj.Spec.Schedule = "CRON_TZ=Europe/Berlin 0 2 * * *"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.schedule")))
+		})
+
+		It("Should deny an unknown time zone", func() {
+			obj.Spec.TimeZone = ptr.To("Mars/Olympus_Mons")
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.timeZone")))
+		})
+	})
+})
@@ -0,0 +1,211 @@
+// Package actuatortest provides a fake of the management API for tests.
+package actuatortest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/camunda/camunda-operator/pkg/actuator"
+)
+
+// Exporting states of the fake.
+const (
+	ExportingRunning    = "RUNNING"
+	ExportingPaused     = "PAUSED"
+	ExportingSoftPaused = "SOFT_PAUSED"
+)
+
+// Server is a fake management API. Backups are created in state IN_PROGRESS and stay there until
+// the test moves them on with SetBackupState or SetWebappsBackupState.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	backups   map[int64]*actuator.Backup
+	webapps   map[int64]*actuator.Backup
+	exporting string
+	// webappsSnapshots are the snapshot names reported for web applications backups,
+	// {id} is replaced with the backup id.
+	webappsSnapshots []string
+}
+
+// NewServer starts a fake management API. It is closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		backups:          map[int64]*actuator.Backup{},
+		webapps:          map[int64]*actuator.Backup{},
+		exporting:        ExportingRunning,
+		webappsSnapshots: []string{"camunda_webapps_{id}_8.8.0_part_1_of_1"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /actuator/backups", s.takeBackup(s.backups, nil))
+	mux.HandleFunc("GET /actuator/backups/{id}", s.getBackup(s.backups))
+	mux.HandleFunc("DELETE /actuator/backups/{id}", s.deleteBackup(s.backups))
+	mux.HandleFunc("POST /actuator/backupHistory", s.takeBackup(s.webapps, s.snapshotDetails))
+	mux.HandleFunc("GET /actuator/backupHistory/{id}", s.getBackup(s.webapps))
+	mux.HandleFunc("DELETE /actuator/backupHistory/{id}", s.deleteBackup(s.webapps))
+	mux.HandleFunc("POST /actuator/exporting/pause", s.pauseExporting)
+	mux.HandleFunc("POST /actuator/exporting/resume", s.resumeExporting)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// BaseURL returns the URL the client is created with.
+func (s *Server) BaseURL() url.URL {
+	u, _ := url.Parse(s.URL)
+	return *u
+}
+
+// SetBackupState moves the broker backup to the state.
+func (s *Server) SetBackupState(backupID int64, state actuator.BackupState, failureReason string) {
+	s.setState(s.backups, backupID, state, failureReason)
+}
+
+// SetWebappsBackupState moves the web applications backup to the state.
+func (s *Server) SetWebappsBackupState(backupID int64, state actuator.BackupState, failureReason string) {
+	s.setState(s.webapps, backupID, state, failureReason)
+}
+
+// HasBackup reports whether a broker backup was taken.
+func (s *Server) HasBackup(backupID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.backups[backupID]
+	return ok
+}
+
+// HasWebappsBackup reports whether a web applications backup was taken.
+func (s *Server) HasWebappsBackup(backupID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.webapps[backupID]
+	return ok
+}
+
+// Exporting returns the exporting state.
+func (s *Server) Exporting() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exporting
+}
+
+func (s *Server) setState(backups map[int64]*actuator.Backup, backupID int64, state actuator.BackupState,
+	failureReason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backup, ok := backups[backupID]
+	if !ok {
+		backup = &actuator.Backup{BackupID: backupID}
+		backups[backupID] = backup
+	}
+	backup.State = state
+	backup.FailureReason = failureReason
+	for i := range backup.Details {
+		backup.Details[i].State = state
+	}
+}
+
+func (s *Server) snapshotDetails(backupID int64) []actuator.BackupDetail {
+	var details []actuator.BackupDetail
+	for _, name := range s.webappsSnapshots {
+		details = append(details, actuator.BackupDetail{
+			SnapshotName: strings.ReplaceAll(name, "{id}", strconv.FormatInt(backupID, 10)),
+			State:        actuator.BackupStateInProgress,
+		})
+	}
+	return details
+}
+
+func (s *Server) takeBackup(
+	backups map[int64]*actuator.Backup,
+	details func(int64) []actuator.BackupDetail,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			BackupID int64 `json:"backupId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.BackupID <= 0 {
+			http.Error(w, `{"message":"invalid backup id"}`, http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, exists := backups[request.BackupID]; exists {
+			http.Error(w, `{"message":"backup already exists"}`, http.StatusConflict)
+			return
+		}
+		backup := &actuator.Backup{BackupID: request.BackupID, State: actuator.BackupStateInProgress}
+		if details != nil {
+			backup.Details = details(request.BackupID)
+		}
+		backups[request.BackupID] = backup
+		writeJSON(w, http.StatusAccepted, map[string]any{"message": "backup started"})
+	}
+}
+
+func (s *Server) getBackup(backups map[int64]*actuator.Backup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		backupID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, `{"message":"invalid backup id"}`, http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		backup, ok := backups[backupID]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, actuator.Backup{BackupID: backupID, State: actuator.BackupStateDoesNotExist})
+			return
+		}
+		writeJSON(w, http.StatusOK, backup)
+	}
+}
+
+func (s *Server) deleteBackup(backups map[int64]*actuator.Backup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		backupID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, `{"message":"invalid backup id"}`, http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := backups[backupID]; !ok {
+			http.Error(w, `{"message":"backup not found"}`, http.StatusNotFound)
+			return
+		}
+		delete(backups, backupID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) pauseExporting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exporting = ExportingPaused
+	if r.URL.Query().Get("soft") == "true" {
+		s.exporting = ExportingSoftPaused
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resumeExporting(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exporting = ExportingRunning
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
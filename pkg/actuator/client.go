@@ -0,0 +1,221 @@
+// Package actuator is a client for the backup and exporting endpoints of the management API
+// a Camunda cluster serves on port 9600. The cluster topology is read with the management client
+// of the Camunda Go SDK, which does not cover these endpoints.
+package actuator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Port is the port of the management API.
+const Port = 9600
+
+// ErrNotFound is returned when the requested backup does not exist.
+var ErrNotFound = errors.New("backup not found")
+
+// BackupState is the state of a backup as reported by the management API.
+type BackupState string
+
+const (
+	BackupStateInProgress   BackupState = "IN_PROGRESS"
+	BackupStateCompleted    BackupState = "COMPLETED"
+	BackupStateFailed       BackupState = "FAILED"
+	BackupStateIncomplete   BackupState = "INCOMPLETE"
+	BackupStateDoesNotExist BackupState = "DOES_NOT_EXIST"
+)
+
+// Failed reports whether the backup ended without completing.
+func (s BackupState) Failed() bool {
+	return s == BackupStateFailed || s == BackupStateIncomplete
+}
+
+// Backup is the state of a broker or web applications backup.
+type Backup struct {
+	BackupID      int64          `json:"backupId"`
+	State         BackupState    `json:"state"`
+	FailureReason string         `json:"failureReason,omitempty"`
+	Details       []BackupDetail `json:"details,omitempty"`
+}
+
+// BackupDetail is the state of the backup of a single partition or a single snapshot.
+type BackupDetail struct {
+	PartitionID   int32       `json:"partitionId,omitempty"`
+	SnapshotName  string      `json:"snapshotName,omitempty"`
+	State         BackupState `json:"state"`
+	FailureReason string      `json:"failureReason,omitempty"`
+}
+
+// SnapshotNames returns the names of the snapshots the backup consists of.
+func (b Backup) SnapshotNames() []string {
+	var names []string
+	for _, detail := range b.Details {
+		if detail.SnapshotName != "" {
+			names = append(names, detail.SnapshotName)
+		}
+	}
+	return names
+}
+
+// Client talks to the management API of a single cluster.
+type Client struct {
+	httpClient *http.Client
+	baseURL    url.URL
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient returns a client for the management API at the base URL, e.g. http://camunda:9600.
+func NewClient(baseURL url.URL, opts ...Option) *Client {
+	c := &Client{httpClient: &http.Client{}, baseURL: baseURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// TakeBackup starts a backup of all partitions of the brokers.
+func (c *Client) TakeBackup(ctx context.Context, backupID int64) error {
+	return c.takeBackup(ctx, "backups", backupID)
+}
+
+// Backup returns the state of the broker backup.
+func (c *Client) Backup(ctx context.Context, backupID int64) (*Backup, error) {
+	return c.backup(ctx, "backups", backupID)
+}
+
+// DeleteBackup deletes the broker backup from the backup store.
+func (c *Client) DeleteBackup(ctx context.Context, backupID int64) error {
+	return c.deleteBackup(ctx, "backups", backupID)
+}
+
+// TakeWebappsBackup starts a backup of the secondary storage indices of the web applications.
+// They are written as snapshots into the repository configured in the cluster.
+func (c *Client) TakeWebappsBackup(ctx context.Context, backupID int64) error {
+	return c.takeBackup(ctx, "backupHistory", backupID)
+}
+
+// WebappsBackup returns the state of the web applications backup.
+func (c *Client) WebappsBackup(ctx context.Context, backupID int64) (*Backup, error) {
+	return c.backup(ctx, "backupHistory", backupID)
+}
+
+// DeleteWebappsBackup deletes the snapshots of the web applications backup.
+func (c *Client) DeleteWebappsBackup(ctx context.Context, backupID int64) error {
+	return c.deleteBackup(ctx, "backupHistory", backupID)
+}
+
+// PauseExporting pauses the exporters of all partitions. A soft pause keeps exporting the records
+// but does not acknowledge them, so they are exported again after a restore.
+func (c *Client) PauseExporting(ctx context.Context, soft bool) error {
+	query := url.Values{}
+	if soft {
+		query.Set("soft", "true")
+	}
+	return c.do(ctx, http.MethodPost, c.url(query, "exporting", "pause"), nil, nil)
+}
+
+// ResumeExporting resumes the exporters of all partitions.
+func (c *Client) ResumeExporting(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, c.url(nil, "exporting", "resume"), nil, nil)
+}
+
+func (c *Client) takeBackup(ctx context.Context, endpoint string, backupID int64) error {
+	body := map[string]int64{"backupId": backupID}
+	return c.do(ctx, http.MethodPost, c.url(nil, endpoint), body, nil)
+}
+
+func (c *Client) backup(ctx context.Context, endpoint string, backupID int64) (*Backup, error) {
+	var backup Backup
+	err := c.do(ctx, http.MethodGet, c.url(nil, endpoint, strconv.FormatInt(backupID, 10)), nil, &backup)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if backup.State == BackupStateDoesNotExist {
+		return nil, ErrNotFound
+	}
+	return &backup, nil
+}
+
+// deleteBackup deletes a backup. Deleting a backup that does not exist succeeds.
+func (c *Client) deleteBackup(ctx context.Context, endpoint string, backupID int64) error {
+	err := c.do(ctx, http.MethodDelete, c.url(nil, endpoint, strconv.FormatInt(backupID, 10)), nil, nil)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) url(query url.Values, elem ...string) url.URL {
+	u := c.baseURL
+	u.Path, _ = url.JoinPath(u.Path, append([]string{"actuator"}, elem...)...)
+	u.RawQuery = query.Encode()
+	return u
+}
+
+func (c *Client) do(ctx context.Context, method string, u url.URL, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return &statusError{method: method, path: u.Path, code: res.StatusCode, message: string(message)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// statusError is returned for responses with an unsuccessful status code.
+type statusError struct {
+	method  string
+	path    string
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s: received status code %d: %s", e.method, e.path, e.code, e.message)
+}
@@ -0,0 +1,70 @@
+package actuator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/actuator/actuatortest"
+)
+
+func TestClient_Backup(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	client := actuator.NewClient(server.BaseURL())
+
+	_, err := client.Backup(t.Context(), 42)
+	assert.ErrorIs(t, err, actuator.ErrNotFound)
+
+	require.NoError(t, client.TakeBackup(t.Context(), 42))
+	backup, err := client.Backup(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, actuator.BackupStateInProgress, backup.State)
+
+	server.SetBackupState(42, actuator.BackupStateFailed, "partition 2 failed")
+	backup, err = client.Backup(t.Context(), 42)
+	require.NoError(t, err)
+	assert.True(t, backup.State.Failed())
+	assert.Equal(t, "partition 2 failed", backup.FailureReason)
+
+	assert.Error(t, client.TakeBackup(t.Context(), 42), "Backup ids cannot be reused")
+
+	require.NoError(t, client.DeleteBackup(t.Context(), 42))
+	assert.False(t, server.HasBackup(42))
+	assert.NoError(t, client.DeleteBackup(t.Context(), 42), "Deleting a missing backup succeeds")
+}
+
+func TestClient_WebappsBackup(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	client := actuator.NewClient(server.BaseURL())
+
+	_, err := client.WebappsBackup(t.Context(), 7)
+	assert.ErrorIs(t, err, actuator.ErrNotFound)
+
+	require.NoError(t, client.TakeWebappsBackup(t.Context(), 7))
+	server.SetWebappsBackupState(7, actuator.BackupStateCompleted, "")
+
+	backup, err := client.WebappsBackup(t.Context(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, actuator.BackupStateCompleted, backup.State)
+	assert.Equal(t, []string{"camunda_webapps_7_8.8.0_part_1_of_1"}, backup.SnapshotNames())
+	assert.False(t, server.HasBackup(7), "Broker backup is taken separately")
+
+	require.NoError(t, client.DeleteWebappsBackup(t.Context(), 7))
+	assert.False(t, server.HasWebappsBackup(7))
+}
+
+func TestClient_Exporting(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	client := actuator.NewClient(server.BaseURL())
+
+	require.NoError(t, client.PauseExporting(t.Context(), true))
+	assert.Equal(t, actuatortest.ExportingSoftPaused, server.Exporting())
+
+	require.NoError(t, client.ResumeExporting(t.Context()))
+	assert.Equal(t, actuatortest.ExportingRunning, server.Exporting())
+}
@@ -0,0 +1,267 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/snapshot"
+)
+
+// PollInterval is the interval in which the progress of a backup or restore is checked.
+const PollInterval = 10 * time.Second
+
+// recordsIndices matches the indices the Elasticsearch and OpenSearch exporters write to.
+const recordsIndices = "zeebe-record*"
+
+// Actuator is the part of the management API needed to take and delete backups.
+// It is implemented by actuator.Client.
+type Actuator interface {
+	TakeBackup(ctx context.Context, backupID int64) error
+	Backup(ctx context.Context, backupID int64) (*actuator.Backup, error)
+	TakeWebappsBackup(ctx context.Context, backupID int64) error
+	WebappsBackup(ctx context.Context, backupID int64) (*actuator.Backup, error)
+	PauseExporting(ctx context.Context, soft bool) error
+	ResumeExporting(ctx context.Context) error
+	DeleteBackup(ctx context.Context, backupID int64) error
+	DeleteWebappsBackup(ctx context.Context, backupID int64) error
+}
+
+// Snapshots is the part of the snapshot API of the database needed to snapshot the exported
+// records, to restore backups and to delete them. It is implemented by snapshot.Client.
+type Snapshots interface {
+	CreateSnapshot(ctx context.Context, repository, name, indices string) error
+	Snapshots(ctx context.Context, repository, patterns string) ([]snapshot.Snapshot, error)
+	RestoreSnapshot(ctx context.Context, repository, name string) error
+	DeleteSnapshot(ctx context.Context, repository, patterns string) error
+}
+
+// Result is the outcome of a single step of the backup state machine.
+type Result struct {
+	// Status is the backup status to persist.
+	Status v1alpha1.OrchestrationClusterBackupStatus
+	// RequeueAfter is set while the backup is in progress.
+	RequeueAfter time.Duration
+}
+
+// ID returns the id of the backup, defaulting to the creation time in unix seconds.
+func ID(backup *v1alpha1.OrchestrationClusterBackup) int64 {
+	if backup.Spec.BackupID != nil {
+		return *backup.Spec.BackupID
+	}
+	return backup.CreationTimestamp.Unix()
+}
+
+// RecordsSnapshotName returns the name of the snapshot of the exported records of a backup.
+func RecordsSnapshotName(backupID int64) string {
+	return fmt.Sprintf("camunda_zeebe_records_backup_%d", backupID)
+}
+
+// HasSecondaryStorage reports whether the cluster keeps its secondary storage in Elasticsearch or
+// OpenSearch, which is backed up as snapshots. PostgreSQL must be backed up with its own tooling.
+func HasSecondaryStorage(spec v1alpha1.OrchestrationClusterSpec) bool {
+	return spec.Database.Type != v1alpha1.PostgresqlDatabaseType
+}
+
+// Done reports whether the backup reached a final phase.
+func Done(phase v1alpha1.BackupPhase) bool {
+	return phase == v1alpha1.BackupPhaseCompleted || phase == v1alpha1.BackupPhaseFailed
+}
+
+// Step advances the backup state machine by one step.
+//
+// The secondary storage is backed up first. Then exporting is soft paused, so the records exported
+// after the snapshot of the secondary storage are exported again after a restore, and the brokers
+// are backed up together with a snapshot of the exported records. Exporting is resumed once the
+// broker backup is completed or failed, when a step fails, and by Abort. Snapshots is nil for
+// clusters without secondary storage.
+func Step(
+	ctx context.Context,
+	backup *v1alpha1.OrchestrationClusterBackup,
+	osc *v1alpha1.OrchestrationCluster,
+	act Actuator,
+	snaps Snapshots,
+	now metav1.Time,
+) (Result, error) {
+	status := *backup.Status.DeepCopy()
+
+	switch status.Phase {
+	case "", v1alpha1.BackupPhasePending:
+		if osc.Spec.Backup == nil {
+			return failed(status, now, fmt.Sprintf("cluster %s has no backup store configured", osc.Name)), nil
+		}
+		status.BackupID = ID(backup)
+		status.StartTime = &now
+		status.Phase = v1alpha1.BackupPhaseBackingUpBrokers
+		if HasSecondaryStorage(osc.Spec) {
+			status.Phase = v1alpha1.BackupPhaseBackingUpSecondaryStorage
+		}
+		status.Message = ""
+		return Result{Status: status}, nil
+
+	case v1alpha1.BackupPhaseBackingUpSecondaryStorage:
+		webapps, err := act.WebappsBackup(ctx, status.BackupID)
+		if errors.Is(err, actuator.ErrNotFound) {
+			if err := act.TakeWebappsBackup(ctx, status.BackupID); err != nil {
+				return Result{}, fmt.Errorf("failed to start backup of the secondary storage: %w", err)
+			}
+			status.Message = "waiting for the backup of the secondary storage"
+			return Result{Status: status, RequeueAfter: PollInterval}, nil
+		}
+		if err != nil {
+			return Result{}, err
+		}
+
+		status.SecondaryStorage = componentStatus(webapps)
+		switch {
+		case webapps.State == actuator.BackupStateCompleted:
+			status.Phase = v1alpha1.BackupPhaseBackingUpBrokers
+			status.Message = ""
+			return Result{Status: status}, nil
+		case webapps.State.Failed():
+			return failed(status, now, "backup of the secondary storage failed: "+webapps.FailureReason), nil
+		default:
+			status.Message = "waiting for the backup of the secondary storage"
+			return Result{Status: status, RequeueAfter: PollInterval}, nil
+		}
+
+	case v1alpha1.BackupPhaseBackingUpBrokers:
+		return stepBrokers(ctx, status, osc, act, snaps, now)
+	}
+
+	return Result{Status: status}, nil
+}
+
+// stepBrokers advances the backup of the brokers. Exporting is paused while a step runs and
+// resumed whenever the step fails, so a backup that keeps failing or is abandoned does not leave
+// exporting paused; it is paused again by the next step.
+func stepBrokers(
+	ctx context.Context,
+	status v1alpha1.OrchestrationClusterBackupStatus,
+	osc *v1alpha1.OrchestrationCluster,
+	act Actuator,
+	snaps Snapshots,
+	now metav1.Time,
+) (Result, error) {
+	result, err := backUpBrokers(ctx, status, osc, act, snaps, now)
+	if err != nil {
+		if resumeErr := act.ResumeExporting(ctx); resumeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to resume exporting: %w", resumeErr))
+		}
+		return Result{}, err
+	}
+	return result, nil
+}
+
+func backUpBrokers(
+	ctx context.Context,
+	status v1alpha1.OrchestrationClusterBackupStatus,
+	osc *v1alpha1.OrchestrationCluster,
+	act Actuator,
+	snaps Snapshots,
+	now metav1.Time,
+) (Result, error) {
+	if err := act.PauseExporting(ctx, true); err != nil {
+		return Result{}, fmt.Errorf("failed to pause exporting: %w", err)
+	}
+	brokers, err := act.Backup(ctx, status.BackupID)
+	if errors.Is(err, actuator.ErrNotFound) {
+		if err := act.TakeBackup(ctx, status.BackupID); err != nil {
+			return Result{}, fmt.Errorf("failed to start backup of the brokers: %w", err)
+		}
+		status.Message = "waiting for the backup of the brokers"
+		return Result{Status: status, RequeueAfter: PollInterval}, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	status.Brokers = componentStatus(brokers)
+
+	if snaps != nil && !brokers.State.Failed() {
+		records, err := snapshotRecords(ctx, snaps, osc.Spec.Backup.RepositoryName, status.BackupID)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to snapshot the exported records: %w", err)
+		}
+		status.Records = records
+	}
+
+	switch {
+	case brokers.State.Failed():
+		if err := act.ResumeExporting(ctx); err != nil {
+			return Result{}, fmt.Errorf("failed to resume exporting: %w", err)
+		}
+		return failed(status, now, "backup of the brokers failed: "+brokers.FailureReason), nil
+	case status.Records != nil && status.Records.State != snapshot.StateSuccess &&
+		status.Records.State != snapshot.StateInProgress:
+		if err := act.ResumeExporting(ctx); err != nil {
+			return Result{}, fmt.Errorf("failed to resume exporting: %w", err)
+		}
+		return failed(status, now, "snapshot of the exported records failed"), nil
+	case brokers.State != actuator.BackupStateCompleted ||
+		status.Records != nil && status.Records.State != snapshot.StateSuccess:
+		status.Message = "waiting for the backup of the brokers"
+		return Result{Status: status, RequeueAfter: PollInterval}, nil
+	}
+
+	if err := act.ResumeExporting(ctx); err != nil {
+		return Result{}, fmt.Errorf("failed to resume exporting: %w", err)
+	}
+	status.Phase = v1alpha1.BackupPhaseCompleted
+	status.Message = ""
+	status.CompletionTime = &now
+	return Result{Status: status}, nil
+}
+
+// Abort resumes exporting if it is paused by the backup of the brokers. It is called when a backup
+// is deleted before it reached a final phase.
+func Abort(ctx context.Context, status v1alpha1.OrchestrationClusterBackupStatus, act Actuator) error {
+	if status.Phase != v1alpha1.BackupPhaseBackingUpBrokers {
+		return nil
+	}
+	if err := act.ResumeExporting(ctx); err != nil {
+		return fmt.Errorf("failed to resume exporting: %w", err)
+	}
+	return nil
+}
+
+// snapshotRecords starts the snapshot of the exported records if it does not exist yet and
+// returns its state.
+func snapshotRecords(
+	ctx context.Context,
+	snaps Snapshots,
+	repository string,
+	backupID int64,
+) (*v1alpha1.ComponentBackupStatus, error) {
+	name := RecordsSnapshotName(backupID)
+	existing, err := snaps.Snapshots(ctx, repository, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		if err := snaps.CreateSnapshot(ctx, repository, name, recordsIndices); err != nil {
+			return nil, err
+		}
+		return &v1alpha1.ComponentBackupStatus{State: snapshot.StateInProgress, Snapshots: []string{name}}, nil
+	}
+	return &v1alpha1.ComponentBackupStatus{State: existing[0].State, Snapshots: []string{name}}, nil
+}
+
+func componentStatus(backup *actuator.Backup) *v1alpha1.ComponentBackupStatus {
+	return &v1alpha1.ComponentBackupStatus{
+		State:         string(backup.State),
+		FailureReason: backup.FailureReason,
+		Snapshots:     backup.SnapshotNames(),
+	}
+}
+
+func failed(status v1alpha1.OrchestrationClusterBackupStatus, now metav1.Time, message string) Result {
+	status.Phase = v1alpha1.BackupPhaseFailed
+	status.Message = message
+	status.CompletionTime = &now
+	return Result{Status: status}
+}
@@ -0,0 +1,276 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/actuator/actuatortest"
+	"github.com/camunda/camunda-operator/pkg/snapshot"
+)
+
+var now = metav1.NewTime(time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC))
+
+// fakeSnapshots is an in memory snapshot repository.
+type fakeSnapshots struct {
+	snapshots map[string]string
+	restored  []string
+}
+
+func newFakeSnapshots() *fakeSnapshots {
+	return &fakeSnapshots{snapshots: map[string]string{}}
+}
+
+func (f *fakeSnapshots) CreateSnapshot(_ context.Context, _, name, _ string) error {
+	f.snapshots[name] = snapshot.StateInProgress
+	return nil
+}
+
+func (f *fakeSnapshots) Snapshots(_ context.Context, _, patterns string) ([]snapshot.Snapshot, error) {
+	var result []snapshot.Snapshot
+	for _, pattern := range strings.Split(patterns, ",") {
+		for name, state := range f.snapshots {
+			if name == pattern || strings.HasSuffix(pattern, "*") && strings.HasPrefix(name, strings.TrimSuffix(pattern, "*")) {
+				result = append(result, snapshot.Snapshot{Snapshot: name, State: state})
+			}
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSnapshots) RestoreSnapshot(_ context.Context, _, name string) error {
+	f.restored = append(f.restored, name)
+	return nil
+}
+
+func (f *fakeSnapshots) DeleteSnapshot(_ context.Context, _, patterns string) error {
+	for _, name := range strings.Split(patterns, ",") {
+		delete(f.snapshots, name)
+	}
+	return nil
+}
+
+func backupCluster(databaseType v1alpha1.DatabaseType) *v1alpha1.OrchestrationCluster {
+	return &v1alpha1.OrchestrationCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "camunda", Namespace: "camunda-ns"},
+		Spec: v1alpha1.OrchestrationClusterSpec{
+			Database: v1alpha1.Database{Type: databaseType},
+			Backup: &v1alpha1.BackupStore{
+				RepositoryName: "camunda-backups",
+				S3:             &v1alpha1.S3BackupStore{BucketName: "backups"},
+			},
+		},
+	}
+}
+
+func newBackup(backupID int64) *v1alpha1.OrchestrationClusterBackup {
+	return &v1alpha1.OrchestrationClusterBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "camunda-ns"},
+		Spec:       v1alpha1.OrchestrationClusterBackupSpec{ClusterName: "camunda", BackupID: ptr.To(backupID)},
+	}
+}
+
+// step runs a step and persists the resulting status like the controller does.
+func step(
+	t *testing.T,
+	b *v1alpha1.OrchestrationClusterBackup,
+	osc *v1alpha1.OrchestrationCluster,
+	server *actuatortest.Server,
+	snaps Snapshots,
+) Result {
+	t.Helper()
+	result, err := Step(t.Context(), b, osc, actuator.NewClient(server.BaseURL()), snaps, now)
+	require.NoError(t, err)
+	b.Status = result.Status
+	return result
+}
+
+func TestID(t *testing.T) {
+	b := newBackup(5)
+	assert.Equal(t, int64(5), ID(b))
+
+	b.Spec.BackupID = nil
+	b.CreationTimestamp = now
+	assert.Equal(t, now.Unix(), ID(b))
+}
+
+func TestStep_Elasticsearch(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	snaps := newFakeSnapshots()
+	osc := backupCluster(v1alpha1.ElasticsearchDatabaseType)
+	b := newBackup(100)
+
+	step(t, b, osc, server, snaps)
+	assert.Equal(t, v1alpha1.BackupPhaseBackingUpSecondaryStorage, b.Status.Phase)
+	assert.Equal(t, int64(100), b.Status.BackupID)
+	assert.Equal(t, &now, b.Status.StartTime)
+
+	result := step(t, b, osc, server, snaps)
+	assert.True(t, server.HasWebappsBackup(100))
+	assert.Equal(t, PollInterval, result.RequeueAfter)
+	assert.False(t, server.HasBackup(100), "Brokers are backed up after the secondary storage")
+
+	server.SetWebappsBackupState(100, actuator.BackupStateCompleted, "")
+	step(t, b, osc, server, snaps)
+	assert.Equal(t, v1alpha1.BackupPhaseBackingUpBrokers, b.Status.Phase)
+	assert.Equal(t, []string{"camunda_webapps_100_8.8.0_part_1_of_1"}, b.Status.SecondaryStorage.Snapshots)
+
+	step(t, b, osc, server, snaps)
+	assert.True(t, server.HasBackup(100))
+	assert.Equal(t, actuatortest.ExportingSoftPaused, server.Exporting())
+
+	step(t, b, osc, server, snaps)
+	assert.Equal(t, snapshot.StateInProgress, snaps.snapshots[RecordsSnapshotName(100)])
+
+	server.SetBackupState(100, actuator.BackupStateCompleted, "")
+	result = step(t, b, osc, server, snaps)
+	assert.Equal(t, v1alpha1.BackupPhaseBackingUpBrokers, b.Status.Phase, "Waits for the records snapshot")
+	assert.Equal(t, PollInterval, result.RequeueAfter)
+	assert.Equal(t, actuatortest.ExportingSoftPaused, server.Exporting())
+
+	snaps.snapshots[RecordsSnapshotName(100)] = snapshot.StateSuccess
+	result = step(t, b, osc, server, snaps)
+	assert.Equal(t, v1alpha1.BackupPhaseCompleted, b.Status.Phase)
+	assert.Equal(t, &now, b.Status.CompletionTime)
+	assert.Zero(t, result.RequeueAfter)
+	assert.Equal(t, actuatortest.ExportingRunning, server.Exporting())
+}
+
+func TestStep_Postgresql(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	osc := backupCluster(v1alpha1.PostgresqlDatabaseType)
+	b := newBackup(3)
+
+	step(t, b, osc, server, nil)
+	assert.Equal(t, v1alpha1.BackupPhaseBackingUpBrokers, b.Status.Phase)
+
+	step(t, b, osc, server, nil)
+	server.SetBackupState(3, actuator.BackupStateCompleted, "")
+	step(t, b, osc, server, nil)
+
+	assert.Equal(t, v1alpha1.BackupPhaseCompleted, b.Status.Phase)
+	assert.False(t, server.HasWebappsBackup(3))
+	assert.Nil(t, b.Status.Records)
+}
+
+func TestStep_BrokerBackupFailed(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	osc := backupCluster(v1alpha1.PostgresqlDatabaseType)
+	b := newBackup(3)
+
+	step(t, b, osc, server, nil)
+	step(t, b, osc, server, nil)
+	server.SetBackupState(3, actuator.BackupStateFailed, "partition 1 is unavailable")
+	step(t, b, osc, server, nil)
+
+	assert.Equal(t, v1alpha1.BackupPhaseFailed, b.Status.Phase)
+	assert.Contains(t, b.Status.Message, "partition 1 is unavailable")
+	assert.Equal(t, actuatortest.ExportingRunning, server.Exporting(), "Exporting is resumed")
+}
+
+func TestStep_SecondaryStorageFailed(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	osc := backupCluster(v1alpha1.ElasticsearchDatabaseType)
+	b := newBackup(8)
+
+	step(t, b, osc, server, newFakeSnapshots())
+	step(t, b, osc, server, newFakeSnapshots())
+	server.SetWebappsBackupState(8, actuator.BackupStateIncomplete, "snapshot repository is read only")
+	step(t, b, osc, server, newFakeSnapshots())
+
+	assert.Equal(t, v1alpha1.BackupPhaseFailed, b.Status.Phase)
+	assert.Equal(t, "INCOMPLETE", b.Status.SecondaryStorage.State)
+	assert.False(t, server.HasBackup(8))
+}
+
+func TestStep_NoBackupStore(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	osc := backupCluster(v1alpha1.ElasticsearchDatabaseType)
+	osc.Spec.Backup = nil
+	b := newBackup(1)
+
+	step(t, b, osc, server, nil)
+
+	assert.Equal(t, v1alpha1.BackupPhaseFailed, b.Status.Phase)
+	assert.Equal(t, "cluster camunda has no backup store configured", b.Status.Message)
+}
+
+// failingTakeBackup is an actuator that fails to start the backup of the brokers.
+type failingTakeBackup struct {
+	*actuator.Client
+}
+
+func (failingTakeBackup) TakeBackup(context.Context, int64) error {
+	return errors.New("no leader for partition 2")
+}
+
+func TestStep_TakeBackupFailedResumesExporting(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	osc := backupCluster(v1alpha1.PostgresqlDatabaseType)
+	b := newBackup(4)
+	step(t, b, osc, server, nil)
+
+	act := failingTakeBackup{Client: actuator.NewClient(server.BaseURL())}
+	_, err := Step(t.Context(), b, osc, act, nil, now)
+
+	require.ErrorContains(t, err, "no leader for partition 2")
+	assert.Equal(t, actuatortest.ExportingRunning, server.Exporting(), "Exporting is resumed")
+	assert.Equal(t, v1alpha1.BackupPhaseBackingUpBrokers, b.Status.Phase, "The backup is retried")
+}
+
+func TestStep_PausesExportingAgainOnRetry(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	osc := backupCluster(v1alpha1.PostgresqlDatabaseType)
+	b := newBackup(6)
+	step(t, b, osc, server, nil)
+	step(t, b, osc, server, nil)
+
+	// Exporting was resumed after a failed step while the broker backup kept running.
+	require.NoError(t, actuator.NewClient(server.BaseURL()).ResumeExporting(t.Context()))
+	step(t, b, osc, server, nil)
+
+	assert.Equal(t, actuatortest.ExportingSoftPaused, server.Exporting())
+}
+
+func TestAbort(t *testing.T) {
+	t.Run("deleting a running backup resumes exporting", func(t *testing.T) {
+		server := actuatortest.NewServer()
+		defer server.Close()
+		osc := backupCluster(v1alpha1.PostgresqlDatabaseType)
+		b := newBackup(7)
+		step(t, b, osc, server, nil)
+		step(t, b, osc, server, nil)
+		require.Equal(t, actuatortest.ExportingSoftPaused, server.Exporting())
+
+		require.NoError(t, Abort(t.Context(), b.Status, actuator.NewClient(server.BaseURL())))
+
+		assert.Equal(t, actuatortest.ExportingRunning, server.Exporting())
+	})
+
+	t.Run("exporting is not touched before the brokers are backed up", func(t *testing.T) {
+		server := actuatortest.NewServer()
+		defer server.Close()
+		require.NoError(t, actuator.NewClient(server.BaseURL()).PauseExporting(t.Context(), false))
+		status := v1alpha1.OrchestrationClusterBackupStatus{Phase: v1alpha1.BackupPhaseBackingUpSecondaryStorage}
+
+		require.NoError(t, Abort(t.Context(), status, actuator.NewClient(server.BaseURL())))
+
+		assert.Equal(t, actuatortest.ExportingPaused, server.Exporting())
+	})
+}
@@ -0,0 +1,42 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// starBit is the bit cron sets in a day field that is not restricted. If both day fields are
+// restricted, a day matches if either field matches.
+const starBit = 1 << 63
+
+// ParseSchedule parses a cron schedule with the fields minute, hour, day of month, month and day of
+// week. Months and days of week may be given by name, and the descriptors like @daily are
+// supported. The schedule is evaluated in the location of the time passed to Next, so the time zone
+// is set on the schedule resource rather than with a TZ= prefix.
+//
+// Like cron, a day field starting with * like */2 is not restricted. The activations of Next are
+// at most five years ahead, a schedule like 0 0 30 2 * never activates and returns the zero time.
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	trimmed := strings.TrimSpace(spec)
+	if strings.HasPrefix(trimmed, "TZ=") || strings.HasPrefix(trimmed, "CRON_TZ=") {
+		return nil, fmt.Errorf("invalid schedule %q: set the time zone in timeZone", spec)
+	}
+	schedule, err := cron.ParseStandard(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+
+	// cron only treats a bare * as unrestricted.
+	fields := strings.Fields(trimmed)
+	if s, ok := schedule.(*cron.SpecSchedule); ok && len(fields) == 5 {
+		if strings.HasPrefix(fields[2], "*") {
+			s.Dom |= starBit
+		}
+		if strings.HasPrefix(fields[4], "*") {
+			s.Dow |= starBit
+		}
+	}
+	return schedule, nil
+}
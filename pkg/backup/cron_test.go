@@ -0,0 +1,138 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "0 0 * * 7",
+		"TZ=Europe/Berlin 0 2 * * *", "CRON_TZ=UTC 0 2 * * *",
+	} {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	from := time.Date(2025, 7, 1, 12, 30, 0, 0, time.UTC) // Tuesday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 7, 1, 12, 31, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2025, 7, 2, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 7, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2025, 7, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * SUN", time.Date(2025, 7, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * JAN,JUL mon-wed", time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 7, 6, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 9-17/4 * * 1-5", time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 12 1,15 * *", time.Date(2025, 7, 15, 12, 30, 0, 0, time.UTC)},
+		// Either day field matches if both are restricted.
+		{"0 0 15 * 3", time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15,16 * 5", time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 3", time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * *", time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)},
+		// A day field starting with * is not restricted, so both day fields have to match.
+		{"0 0 */2 * 3", time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */3", time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)},
+		// Steps apply to ranges and start values too.
+		{"10-40/15 * * * *", time.Date(2025, 7, 1, 12, 40, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2025, 7, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 0 1-10/3 * *", time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 */5 *", time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)},
+		// Schedules without any activation stop searching.
+		{"0 0 30 2 *", time.Time{}},
+		{"0 0 31 2 *", time.Time{}},
+		{"0 0 31 4,6,9,11 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	schedule, err := ParseSchedule("0 2 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC).In(berlin))
+
+	assert.Equal(t, time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestSchedule_NextAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want []time.Time
+	}{
+		{
+			// Clocks spring forward from 02:00 to 03:00 on 2025-03-30.
+			name: "skipped time does not activate",
+			spec: "30 2 * * *",
+			from: time.Date(2025, 3, 29, 12, 0, 0, 0, berlin),
+			want: []time.Time{time.Date(2025, 3, 31, 0, 30, 0, 0, time.UTC)},
+		},
+		{
+			name: "time after the skipped hour activates",
+			spec: "0 3 * * *",
+			from: time.Date(2025, 3, 29, 12, 0, 0, 0, berlin),
+			want: []time.Time{time.Date(2025, 3, 30, 1, 0, 0, 0, time.UTC)},
+		},
+		{
+			// Clocks fall back from 03:00 to 02:00 on 2025-10-26.
+			name: "repeated time activates on both occurrences",
+			spec: "*/30 * * * *",
+			from: time.Date(2025, 10, 26, 1, 45, 0, 0, berlin),
+			want: []time.Time{
+				time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC),
+				time.Date(2025, 10, 26, 1, 0, 0, 0, time.UTC),
+				time.Date(2025, 10, 26, 1, 30, 0, 0, time.UTC),
+				time.Date(2025, 10, 26, 2, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "daily schedule after the repeated hour",
+			spec: "0 3 * * *",
+			from: time.Date(2025, 10, 25, 12, 0, 0, 0, berlin),
+			want: []time.Time{
+				time.Date(2025, 10, 26, 2, 0, 0, 0, time.UTC),
+				time.Date(2025, 10, 27, 2, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+
+			next := tt.from
+			for _, want := range tt.want {
+				next = schedule.Next(next)
+				assert.Equal(t, want, next.UTC())
+			}
+		})
+	}
+}
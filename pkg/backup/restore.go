@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// RestoreAnnotation is set on a cluster while its brokers restore their data from a backup.
+// It holds the id of the backup.
+const RestoreAnnotation = "core.camunda.io/restore-backup-id"
+
+// RestoreResult is the outcome of a single step of the restore state machine.
+type RestoreResult struct {
+	// Status is the restore status to persist.
+	Status v1alpha1.OrchestrationClusterRestoreStatus
+	// Cluster is the cluster to create, or to update if it exists. It is nil if it is unchanged.
+	Cluster *v1alpha1.OrchestrationCluster
+	// RequeueAfter is set while the restore is in progress.
+	RequeueAfter time.Duration
+}
+
+// RestoreBackupID returns the id of the backup the brokers of the cluster restore from.
+func RestoreBackupID(osc *v1alpha1.OrchestrationCluster) (int64, bool) {
+	value, ok := osc.Annotations[RestoreAnnotation]
+	if !ok {
+		return 0, false
+	}
+	backupID, err := strconv.ParseInt(value, 10, 64)
+	return backupID, err == nil
+}
+
+// RestoreDone reports whether the restore reached a final phase.
+func RestoreDone(phase v1alpha1.RestorePhase) bool {
+	return phase == v1alpha1.RestorePhaseCompleted || phase == v1alpha1.RestorePhaseFailed
+}
+
+// RestoreStep advances the restore state machine by one step.
+//
+// The snapshots of the secondary storage are restored first, before any broker can export into it.
+// Then the cluster is created with the RestoreAnnotation, which makes every broker restore its
+// data before it starts. Once the cluster is ready, the annotation is removed again. The cluster
+// is nil if it does not exist, Snapshots is nil for clusters without secondary storage.
+func RestoreStep(
+	ctx context.Context,
+	restore *v1alpha1.OrchestrationClusterRestore,
+	osc *v1alpha1.OrchestrationCluster,
+	snaps Snapshots,
+	now metav1.Time,
+) (RestoreResult, error) {
+	status := *restore.Status.DeepCopy()
+	template := restore.Spec.Cluster
+	backupID := restore.Spec.BackupID
+
+	switch status.Phase {
+	case "", v1alpha1.RestorePhasePending:
+		if osc != nil {
+			// A cluster restoring the same backup was created by this restore before it was interrupted.
+			if id, ok := RestoreBackupID(osc); !ok || id != backupID {
+				return restoreFailed(status, now, fmt.Sprintf("cluster %s already exists", template.Name)), nil
+			}
+		}
+		status.StartTime = &now
+		status.Phase = v1alpha1.RestorePhaseRestoringBrokers
+		if HasSecondaryStorage(template.Spec) {
+			status.Phase = v1alpha1.RestorePhaseRestoringSecondaryStorage
+		}
+		return RestoreResult{Status: status}, nil
+
+	case v1alpha1.RestorePhaseRestoringSecondaryStorage:
+		repository := template.Spec.Backup.RepositoryName
+		patterns := fmt.Sprintf("camunda_webapps_%d_*,%s", backupID, RecordsSnapshotName(backupID))
+		snapshots, err := snaps.Snapshots(ctx, repository, patterns)
+		if err != nil {
+			return RestoreResult{}, fmt.Errorf("failed to list snapshots of backup %d: %w", backupID, err)
+		}
+		if len(snapshots) == 0 {
+			return restoreFailed(status, now,
+				fmt.Sprintf("backup %d not found in snapshot repository %s", backupID, repository)), nil
+		}
+
+		for _, s := range snapshots {
+			if slices.Contains(status.RestoredSnapshots, s.Snapshot) {
+				continue
+			}
+			if s.Failed() {
+				return restoreFailed(status, now, fmt.Sprintf("snapshot %s is %s", s.Snapshot, s.State)), nil
+			}
+			if err := snaps.RestoreSnapshot(ctx, repository, s.Snapshot); err != nil {
+				return RestoreResult{}, fmt.Errorf("failed to restore snapshot %s: %w", s.Snapshot, err)
+			}
+			status.RestoredSnapshots = append(status.RestoredSnapshots, s.Snapshot)
+		}
+		status.Phase = v1alpha1.RestorePhaseRestoringBrokers
+		return RestoreResult{Status: status}, nil
+
+	case v1alpha1.RestorePhaseRestoringBrokers:
+		if osc == nil {
+			status.Message = fmt.Sprintf("creating cluster %s", template.Name)
+			return RestoreResult{Status: status, Cluster: restoredCluster(restore), RequeueAfter: PollInterval}, nil
+		}
+		if !meta.IsStatusConditionTrue(osc.Status.Conditions, v1alpha1.ConditionReady) {
+			status.Message = "waiting for the brokers to restore their data"
+			return RestoreResult{Status: status, RequeueAfter: PollInterval}, nil
+		}
+
+		cluster := osc.DeepCopy()
+		delete(cluster.Annotations, RestoreAnnotation)
+		status.Phase = v1alpha1.RestorePhaseCompleted
+		status.Message = ""
+		status.CompletionTime = &now
+		return RestoreResult{Status: status, Cluster: cluster}, nil
+	}
+
+	return RestoreResult{Status: status}, nil
+}
+
+// restoredCluster returns the cluster to create from the template of the restore.
+func restoredCluster(restore *v1alpha1.OrchestrationClusterRestore) *v1alpha1.OrchestrationCluster {
+	return &v1alpha1.OrchestrationCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restore.Spec.Cluster.Name,
+			Namespace: restore.Namespace,
+			Annotations: map[string]string{
+				RestoreAnnotation: strconv.FormatInt(restore.Spec.BackupID, 10),
+			},
+		},
+		Spec: *restore.Spec.Cluster.Spec.DeepCopy(),
+	}
+}
+
+func restoreFailed(status v1alpha1.OrchestrationClusterRestoreStatus, now metav1.Time, message string) RestoreResult {
+	status.Phase = v1alpha1.RestorePhaseFailed
+	status.Message = message
+	status.CompletionTime = &now
+	return RestoreResult{Status: status}
+}
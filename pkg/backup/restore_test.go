@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/snapshot"
+)
+
+func newRestore(databaseType v1alpha1.DatabaseType) *v1alpha1.OrchestrationClusterRestore {
+	return &v1alpha1.OrchestrationClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "camunda-ns"},
+		Spec: v1alpha1.OrchestrationClusterRestoreSpec{
+			BackupID: 100,
+			Cluster: v1alpha1.ClusterTemplate{
+				Name: "camunda",
+				Spec: backupCluster(databaseType).Spec,
+			},
+		},
+	}
+}
+
+func restoreStep(
+	t *testing.T,
+	r *v1alpha1.OrchestrationClusterRestore,
+	osc *v1alpha1.OrchestrationCluster,
+	snaps Snapshots,
+) RestoreResult {
+	t.Helper()
+	result, err := RestoreStep(t.Context(), r, osc, snaps, now)
+	require.NoError(t, err)
+	r.Status = result.Status
+	return result
+}
+
+func TestRestoreStep_Elasticsearch(t *testing.T) {
+	snaps := newFakeSnapshots()
+	snaps.snapshots["camunda_webapps_100_8.8.0_part_1_of_1"] = snapshot.StateSuccess
+	snaps.snapshots[RecordsSnapshotName(100)] = snapshot.StateSuccess
+	snaps.snapshots["camunda_webapps_99_8.8.0_part_1_of_1"] = snapshot.StateSuccess
+	r := newRestore(v1alpha1.ElasticsearchDatabaseType)
+
+	restoreStep(t, r, nil, snaps)
+	assert.Equal(t, v1alpha1.RestorePhaseRestoringSecondaryStorage, r.Status.Phase)
+
+	result := restoreStep(t, r, nil, snaps)
+	assert.Equal(t, v1alpha1.RestorePhaseRestoringBrokers, r.Status.Phase)
+	assert.ElementsMatch(t, []string{"camunda_webapps_100_8.8.0_part_1_of_1", RecordsSnapshotName(100)}, snaps.restored)
+	assert.Nil(t, result.Cluster, "The cluster is created after the secondary storage is restored")
+
+	result = restoreStep(t, r, nil, snaps)
+	require.NotNil(t, result.Cluster)
+	assert.Equal(t, "camunda", result.Cluster.Name)
+	assert.Equal(t, "camunda-ns", result.Cluster.Namespace)
+	backupID, ok := RestoreBackupID(result.Cluster)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), backupID)
+
+	osc := result.Cluster
+	result = restoreStep(t, r, osc, snaps)
+	assert.Equal(t, v1alpha1.RestorePhaseRestoringBrokers, r.Status.Phase)
+	assert.Nil(t, result.Cluster)
+	assert.Equal(t, PollInterval, result.RequeueAfter)
+
+	meta.SetStatusCondition(&osc.Status.Conditions, metav1.Condition{
+		Type:   v1alpha1.ConditionReady,
+		Status: metav1.ConditionTrue,
+		Reason: v1alpha1.ReasonClusterReady,
+	})
+	result = restoreStep(t, r, osc, snaps)
+	assert.Equal(t, v1alpha1.RestorePhaseCompleted, r.Status.Phase)
+	require.NotNil(t, result.Cluster)
+	_, ok = RestoreBackupID(result.Cluster)
+	assert.False(t, ok, "The brokers stop restoring once the cluster is ready")
+}
+
+func TestRestoreStep_Postgresql(t *testing.T) {
+	r := newRestore(v1alpha1.PostgresqlDatabaseType)
+
+	restoreStep(t, r, nil, nil)
+	assert.Equal(t, v1alpha1.RestorePhaseRestoringBrokers, r.Status.Phase)
+
+	result := restoreStep(t, r, nil, nil)
+	assert.NotNil(t, result.Cluster)
+}
+
+func TestRestoreStep_ClusterExists(t *testing.T) {
+	r := newRestore(v1alpha1.ElasticsearchDatabaseType)
+	existing := backupCluster(v1alpha1.ElasticsearchDatabaseType)
+
+	restoreStep(t, r, existing, newFakeSnapshots())
+
+	assert.Equal(t, v1alpha1.RestorePhaseFailed, r.Status.Phase)
+	assert.Equal(t, "cluster camunda already exists", r.Status.Message)
+}
+
+func TestRestoreStep_BackupNotFound(t *testing.T) {
+	r := newRestore(v1alpha1.ElasticsearchDatabaseType)
+
+	restoreStep(t, r, nil, newFakeSnapshots())
+	restoreStep(t, r, nil, newFakeSnapshots())
+
+	assert.Equal(t, v1alpha1.RestorePhaseFailed, r.Status.Phase)
+	assert.Equal(t, "backup 100 not found in snapshot repository camunda-backups", r.Status.Message)
+}
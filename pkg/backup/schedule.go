@@ -0,0 +1,201 @@
+package backup
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// ScheduleLabel is set on the backups created by a schedule. It holds the name of the schedule.
+const ScheduleLabel = "core.camunda.io/backup-schedule"
+
+// ScheduleResult is the outcome of evaluating a backup schedule.
+type ScheduleResult struct {
+	// Status is the schedule status to persist.
+	Status v1alpha1.OrchestrationClusterBackupScheduleStatus
+	// Create is the backup to create. It is nil if no backup is due.
+	Create *v1alpha1.OrchestrationClusterBackup
+	// Prune are the backups to delete from the backup store and from the API server.
+	Prune []*v1alpha1.OrchestrationClusterBackup
+	// Failed are the backups that failed since the last evaluation.
+	Failed []*v1alpha1.OrchestrationClusterBackup
+	// RequeueAfter is the time until the next scheduled backup.
+	RequeueAfter time.Duration
+}
+
+// Location returns the time zone the schedule is evaluated in.
+func Location(schedule *v1alpha1.OrchestrationClusterBackupSchedule) (*time.Location, error) {
+	if schedule.Spec.TimeZone == nil || *schedule.Spec.TimeZone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(*schedule.Spec.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", *schedule.Spec.TimeZone, err)
+	}
+	return location, nil
+}
+
+// EvaluateSchedule determines the backup to create, the backups to prune and the status of a
+// schedule from the backups it created.
+//
+// Like a CronJob with the Forbid concurrency policy, no backup is created while one is in
+// progress, and only the latest of the missed activations is caught up on. The id of a scheduled
+// backup is the activation time in unix seconds, so ids increase with every backup.
+func EvaluateSchedule(
+	schedule *v1alpha1.OrchestrationClusterBackupSchedule,
+	backups []v1alpha1.OrchestrationClusterBackup,
+	now time.Time,
+) (ScheduleResult, error) {
+	cron, err := ParseSchedule(schedule.Spec.Schedule)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	location, err := Location(schedule)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+
+	result := ScheduleResult{Status: *schedule.Status.DeepCopy()}
+	status := &result.Status
+	status.Active = ""
+	for i := range backups {
+		b := &backups[i]
+		switch b.Status.Phase {
+		case v1alpha1.BackupPhaseCompleted:
+			if later(b.Status.CompletionTime, status.LastSuccessfulTime) {
+				status.LastSuccessfulTime = b.Status.CompletionTime
+			}
+		case v1alpha1.BackupPhaseFailed:
+			if later(b.Status.CompletionTime, schedule.Status.LastFailureTime) {
+				result.Failed = append(result.Failed, b)
+			}
+			if later(b.Status.CompletionTime, status.LastFailureTime) {
+				status.LastFailureTime = b.Status.CompletionTime
+				status.LastFailureMessage = b.Status.Message
+			}
+		default:
+			status.Active = b.Name
+		}
+	}
+	result.Prune = Prune(backups, schedule.Spec.Retention, location)
+
+	earliest := schedule.CreationTimestamp.Time
+	if status.LastScheduleTime != nil {
+		earliest = status.LastScheduleTime.Time
+	}
+	var due time.Time
+	next := cron.Next(earliest.In(location))
+	for !next.IsZero() && !next.After(now) {
+		due = next
+		next = cron.Next(next)
+	}
+	if !next.IsZero() {
+		result.RequeueAfter = next.Sub(now)
+	}
+
+	if due.IsZero() || schedule.Spec.Suspend || status.Active != "" {
+		return result, nil
+	}
+	result.Create = scheduledBackup(schedule, due)
+	status.Active = result.Create.Name
+	status.LastScheduleTime = &metav1.Time{Time: due}
+	return result, nil
+}
+
+// Prune returns the backups outside of the retention policy. The newest completed backup of each of
+// the last days and weeks is kept, the newest completed backup is always kept. Failed backups are
+// pruned once a newer backup completed, backups in progress are never pruned.
+func Prune(
+	backups []v1alpha1.OrchestrationClusterBackup,
+	retention v1alpha1.BackupRetention,
+	location *time.Location,
+) []*v1alpha1.OrchestrationClusterBackup {
+	sorted := make([]*v1alpha1.OrchestrationClusterBackup, 0, len(backups))
+	for i := range backups {
+		sorted = append(sorted, &backups[i])
+	}
+	slices.SortFunc(sorted, func(a, b *v1alpha1.OrchestrationClusterBackup) int {
+		return cmp.Compare(b.Status.BackupID, a.Status.BackupID)
+	})
+
+	var prune []*v1alpha1.OrchestrationClusterBackup
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	newestCompleted := true
+	for _, b := range sorted {
+		switch b.Status.Phase {
+		case v1alpha1.BackupPhaseCompleted:
+			taken := time.Unix(b.Status.BackupID, 0).In(location)
+			day := taken.Format(time.DateOnly)
+			year, week := taken.ISOWeek()
+			weekKey := fmt.Sprintf("%d-%d", year, week)
+
+			keep := newestCompleted
+			if !days[day] && len(days) < int(retention.Daily) {
+				days[day] = true
+				keep = true
+			}
+			if !weeks[weekKey] && len(weeks) < int(retention.Weekly) {
+				weeks[weekKey] = true
+				keep = true
+			}
+			if !keep {
+				prune = append(prune, b)
+			}
+			newestCompleted = false
+		case v1alpha1.BackupPhaseFailed:
+			if !newestCompleted {
+				prune = append(prune, b)
+			}
+		}
+	}
+	return prune
+}
+
+// Delete deletes all parts of a backup from the backup store. Parts that do not exist are skipped,
+// so a partially deleted backup is deleted completely on retry. Snapshots is nil for clusters
+// without secondary storage.
+func Delete(ctx context.Context, act Actuator, snaps Snapshots, repository string, backupID int64) error {
+	if err := act.DeleteBackup(ctx, backupID); err != nil {
+		return fmt.Errorf("failed to delete backup of the brokers: %w", err)
+	}
+	if snaps == nil {
+		return nil
+	}
+	if err := snaps.DeleteSnapshot(ctx, repository, RecordsSnapshotName(backupID)); err != nil {
+		return fmt.Errorf("failed to delete snapshot of the exported records: %w", err)
+	}
+	if err := act.DeleteWebappsBackup(ctx, backupID); err != nil {
+		return fmt.Errorf("failed to delete backup of the secondary storage: %w", err)
+	}
+	return nil
+}
+
+func scheduledBackup(
+	schedule *v1alpha1.OrchestrationClusterBackupSchedule,
+	due time.Time,
+) *v1alpha1.OrchestrationClusterBackup {
+	backupID := due.Unix()
+	return &v1alpha1.OrchestrationClusterBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", schedule.Name, backupID),
+			Namespace: schedule.Namespace,
+			Labels:    map[string]string{ScheduleLabel: schedule.Name},
+		},
+		Spec: v1alpha1.OrchestrationClusterBackupSpec{
+			ClusterName: schedule.Spec.ClusterName,
+			BackupID:    &backupID,
+		},
+	}
+}
+
+// later reports whether t is set and after other.
+func later(t, other *metav1.Time) bool {
+	return t != nil && (other == nil || t.After(other.Time))
+}
@@ -0,0 +1,196 @@
+package backup
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/actuator"
+	"github.com/camunda/camunda-operator/pkg/actuator/actuatortest"
+)
+
+func newSchedule() *v1alpha1.OrchestrationClusterBackupSchedule {
+	return &v1alpha1.OrchestrationClusterBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "camunda-ns",
+			CreationTimestamp: metav1.NewTime(now.Add(-36 * time.Hour)),
+		},
+		Spec: v1alpha1.OrchestrationClusterBackupScheduleSpec{
+			ClusterName: "camunda",
+			Schedule:    "0 2 * * *",
+			Retention:   v1alpha1.BackupRetention{Daily: 7, Weekly: 4},
+		},
+	}
+}
+
+// scheduledAt returns a backup taken at the time that reached the phase.
+func scheduledAt(taken time.Time, phase v1alpha1.BackupPhase) v1alpha1.OrchestrationClusterBackup {
+	b := newBackup(taken.Unix())
+	b.Name = "nightly-" + taken.Format(time.DateOnly)
+	b.Status = v1alpha1.OrchestrationClusterBackupStatus{BackupID: taken.Unix(), Phase: phase}
+	if Done(phase) {
+		b.Status.CompletionTime = ptr.To(metav1.NewTime(taken.Add(time.Minute)))
+	}
+	return *b
+}
+
+func names(backups []*v1alpha1.OrchestrationClusterBackup) []string {
+	var result []string
+	for _, b := range backups {
+		result = append(result, b.Name)
+	}
+	return result
+}
+
+func TestEvaluateSchedule_CreatesLatestMissedBackup(t *testing.T) {
+	schedule := newSchedule()
+
+	result, err := EvaluateSchedule(schedule, nil, now.Time)
+	require.NoError(t, err)
+
+	due := time.Date(2025, 7, 1, 2, 0, 0, 0, time.UTC)
+	require.NotNil(t, result.Create)
+	assert.Equal(t, "nightly-1751335200", result.Create.Name)
+	assert.Equal(t, "nightly", result.Create.Labels[ScheduleLabel])
+	assert.Equal(t, "camunda", result.Create.Spec.ClusterName)
+	assert.Equal(t, due.Unix(), *result.Create.Spec.BackupID)
+	assert.Equal(t, due, result.Status.LastScheduleTime.Time)
+	assert.Equal(t, result.Create.Name, result.Status.Active)
+	assert.Equal(t, 14*time.Hour, result.RequeueAfter)
+
+	schedule.Status = result.Status
+	result, err = EvaluateSchedule(schedule, nil, now.Time)
+	require.NoError(t, err)
+	assert.Nil(t, result.Create, "The activation is only run once")
+}
+
+func TestEvaluateSchedule_WaitsForActiveBackup(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Status.LastScheduleTime = ptr.To(metav1.NewTime(time.Date(2025, 6, 30, 2, 0, 0, 0, time.UTC)))
+	active := scheduledAt(time.Date(2025, 6, 30, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseBackingUpBrokers)
+
+	result, err := EvaluateSchedule(schedule, []v1alpha1.OrchestrationClusterBackup{active}, now.Time)
+	require.NoError(t, err)
+
+	assert.Nil(t, result.Create)
+	assert.Equal(t, active.Name, result.Status.Active)
+	assert.Equal(t, schedule.Status.LastScheduleTime, result.Status.LastScheduleTime)
+}
+
+func TestEvaluateSchedule_Suspended(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Spec.Suspend = true
+
+	result, err := EvaluateSchedule(schedule, nil, now.Time)
+	require.NoError(t, err)
+
+	assert.Nil(t, result.Create)
+	assert.Nil(t, result.Status.LastScheduleTime)
+}
+
+func TestEvaluateSchedule_TimeZone(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Spec.TimeZone = ptr.To("America/New_York")
+
+	result, err := EvaluateSchedule(schedule, nil, now.Time)
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Create)
+	assert.Equal(t, time.Date(2025, 7, 1, 6, 0, 0, 0, time.UTC), result.Status.LastScheduleTime.UTC())
+}
+
+func TestEvaluateSchedule_Invalid(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Spec.Schedule = "0 2 * *"
+	_, err := EvaluateSchedule(schedule, nil, now.Time)
+	assert.Error(t, err)
+
+	schedule = newSchedule()
+	schedule.Spec.TimeZone = ptr.To("Mars/Olympus_Mons")
+	_, err = EvaluateSchedule(schedule, nil, now.Time)
+	assert.ErrorContains(t, err, "invalid time zone")
+}
+
+func TestEvaluateSchedule_Status(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Status.LastScheduleTime = ptr.To(metav1.NewTime(time.Date(2025, 7, 1, 2, 0, 0, 0, time.UTC)))
+	completed := scheduledAt(time.Date(2025, 6, 30, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseCompleted)
+	failed := scheduledAt(time.Date(2025, 7, 1, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseFailed)
+	failed.Status.Message = "backup of the brokers failed: disk full"
+	backups := []v1alpha1.OrchestrationClusterBackup{completed, failed}
+
+	result, err := EvaluateSchedule(schedule, backups, now.Time)
+	require.NoError(t, err)
+
+	assert.Equal(t, completed.Status.CompletionTime, result.Status.LastSuccessfulTime)
+	assert.Equal(t, failed.Status.CompletionTime, result.Status.LastFailureTime)
+	assert.Equal(t, "backup of the brokers failed: disk full", result.Status.LastFailureMessage)
+	assert.Equal(t, []string{failed.Name}, names(result.Failed))
+	assert.Empty(t, result.Status.Active)
+
+	schedule.Status = result.Status
+	result, err = EvaluateSchedule(schedule, backups, now.Time)
+	require.NoError(t, err)
+	assert.Empty(t, result.Failed, "Failures are reported once")
+}
+
+func TestPrune(t *testing.T) {
+	// Daily backups from Wednesday 2025-05-28 to Tuesday 2025-07-01.
+	var backups []v1alpha1.OrchestrationClusterBackup
+	for day := time.Date(2025, 5, 28, 2, 0, 0, 0, time.UTC); !day.After(now.Time); day = day.AddDate(0, 0, 1) {
+		backups = append(backups, scheduledAt(day, v1alpha1.BackupPhaseCompleted))
+	}
+
+	prune := Prune(backups, v1alpha1.BackupRetention{Daily: 3, Weekly: 3}, time.UTC)
+
+	var kept []string
+	for _, b := range backups {
+		if !slices.Contains(names(prune), b.Name) {
+			kept = append(kept, b.Name)
+		}
+	}
+	assert.Equal(t, []string{
+		"nightly-2025-06-22", // newest of the week starting 2025-06-16
+		"nightly-2025-06-29", // newest of the week starting 2025-06-23
+		"nightly-2025-06-30",
+		"nightly-2025-07-01", // newest of the current week
+	}, kept)
+}
+
+func TestPrune_KeepsNewestAndActiveBackups(t *testing.T) {
+	backups := []v1alpha1.OrchestrationClusterBackup{
+		scheduledAt(time.Date(2025, 6, 28, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseFailed),
+		scheduledAt(time.Date(2025, 6, 29, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseCompleted),
+		scheduledAt(time.Date(2025, 6, 30, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseFailed),
+		scheduledAt(time.Date(2025, 7, 1, 2, 0, 0, 0, time.UTC), v1alpha1.BackupPhaseBackingUpBrokers),
+	}
+
+	prune := Prune(backups, v1alpha1.BackupRetention{}, time.UTC)
+
+	assert.Equal(t, []string{"nightly-2025-06-28"}, names(prune),
+		"The newest completed backup and the failure after it are kept")
+}
+
+func TestDelete(t *testing.T) {
+	server := actuatortest.NewServer()
+	defer server.Close()
+	act := actuator.NewClient(server.BaseURL())
+	snaps := newFakeSnapshots()
+	require.NoError(t, act.TakeBackup(t.Context(), 100))
+	require.NoError(t, act.TakeWebappsBackup(t.Context(), 100))
+	snaps.snapshots[RecordsSnapshotName(100)] = "SUCCESS"
+
+	require.NoError(t, Delete(t.Context(), act, snaps, "camunda-backups", 100))
+
+	assert.False(t, server.HasBackup(100))
+	assert.False(t, server.HasWebappsBackup(100))
+	assert.Empty(t, snaps.snapshots)
+	assert.NoError(t, Delete(t.Context(), act, snaps, "camunda-backups", 100), "Deleting twice succeeds")
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/camunda/camunda-operator/pkg/bundles/mycustom"
 )
 
+// DefaultVersion is the version used when spec.version is not set.
+const DefaultVersion = "8.7.7"
+
 type VersionStrategy interface {
 	BuildResources(v1alpha1.OrchestrationCluster) ([]client.Object, error)
 }
@@ -31,11 +34,9 @@ func New(osc v1alpha1.OrchestrationCluster) (*Bundle, error) {
 	// Our current strategies
 	strategies := map[string]VersionStrategy{">= 8.7.0-alpha1": mycustom.Strategy{}}
 
-	// TODO: Check how we actually want to default the version.
-	// on API? How to handle updates when version is not set in CRD?
-	const defaultImageVersion = "8.7.7"
+	// The version is defaulted by the webhook, this covers clusters created without it.
 	if osc.Spec.Version == "" {
-		osc.Spec.Version = defaultImageVersion
+		osc.Spec.Version = DefaultVersion
 	}
 
 	return newWithStrategies(osc, strategies)
@@ -0,0 +1,102 @@
+package mycustom
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/identity"
+)
+
+// authenticationConstraint is the range of versions that support configuring the authentication method.
+const authenticationConstraint = ">= 8.8.0-0"
+
+// validateAuthentication rejects authentication methods the given version cannot be configured with.
+func validateAuthentication(version string, authentication *v1alpha1.Authentication) error {
+	if authentication == nil || (authentication.Basic == nil && authentication.OIDC == nil) {
+		return nil
+	}
+	ok, err := supportsVersion(version, authenticationConstraint)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("authentication requires version %s, got %s", authenticationConstraint, version)
+	}
+	return nil
+}
+
+// authenticationEnv configures the authentication method and the authorization checks.
+func authenticationEnv(camunda v1alpha1.OrchestrationCluster) []corev1.EnvVar {
+	authentication := camunda.Spec.Authentication
+	if authentication == nil {
+		authentication = &v1alpha1.Authentication{}
+	}
+
+	e := []corev1.EnvVar{
+		{
+			Name:  "CAMUNDA_SECURITY_AUTHORIZATIONS_ENABLED",
+			Value: strconv.FormatBool(ptr.Deref(authentication.Authorizations, true)),
+		},
+		{
+			Name:  "CAMUNDA_SECURITY_AUTHENTICATION_UNPROTECTEDAPI",
+			Value: strconv.FormatBool(authentication.UnprotectedAPI),
+		},
+	}
+	if basic := authentication.Basic; basic != nil {
+		e = append(e, basicAuthenticationEnv(*basic)...)
+	}
+	if oidc := authentication.OIDC; oidc != nil {
+		e = append(e, oidcEnv(*oidc)...)
+	}
+	return e
+}
+
+// basicAuthenticationEnv creates the initial user from the admin Secret and grants it the admin role.
+func basicAuthenticationEnv(basic v1alpha1.BasicAuthentication) []corev1.EnvVar {
+	e := []corev1.EnvVar{
+		{Name: "CAMUNDA_SECURITY_AUTHENTICATION_METHOD", Value: "basic"},
+		secretEnv("CAMUNDA_SECURITY_INITIALIZATION_USERS_0_USERNAME", basic.AdminSecret, identity.AdminUsernameKey),
+		secretEnv("CAMUNDA_SECURITY_INITIALIZATION_USERS_0_PASSWORD", basic.AdminSecret, identity.AdminPasswordKey),
+		secretEnv("CAMUNDA_SECURITY_INITIALIZATION_DEFAULTROLES_ADMIN_USERS_0", basic.AdminSecret, identity.AdminUsernameKey),
+	}
+	return append(e, nonEmptyEnv(
+		corev1.EnvVar{Name: "CAMUNDA_SECURITY_INITIALIZATION_USERS_0_NAME", Value: basic.Name},
+		corev1.EnvVar{Name: "CAMUNDA_SECURITY_INITIALIZATION_USERS_0_EMAIL", Value: basic.Email},
+	)...)
+}
+
+func oidcEnv(oidc v1alpha1.OIDCAuthentication) []corev1.EnvVar {
+	clientSecret := oidc.ClientSecret
+	e := []corev1.EnvVar{
+		{Name: "CAMUNDA_SECURITY_AUTHENTICATION_METHOD", Value: "oidc"},
+		{Name: "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_ISSUERURI", Value: oidc.IssuerURL},
+		{Name: "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_CLIENTID", Value: oidc.ClientID},
+		{
+			Name:      "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_CLIENTSECRET",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &clientSecret},
+		},
+	}
+	e = append(e, nonEmptyEnv(
+		corev1.EnvVar{Name: "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_REDIRECTURI", Value: oidc.RedirectURI},
+		corev1.EnvVar{Name: "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_USERNAMECLAIM", Value: oidc.UsernameClaim},
+		corev1.EnvVar{Name: "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_CLIENTIDCLAIM", Value: oidc.ClientIDClaim},
+		corev1.EnvVar{Name: "CAMUNDA_SECURITY_AUTHENTICATION_OIDC_GROUPSCLAIM", Value: oidc.GroupsClaim},
+	)...)
+	for i, audience := range oidc.Audiences {
+		e = append(e, corev1.EnvVar{
+			Name:  fmt.Sprintf("CAMUNDA_SECURITY_AUTHENTICATION_OIDC_AUDIENCES_%d", i),
+			Value: audience,
+		})
+	}
+	for i, username := range oidc.AdminUsers {
+		e = append(e, corev1.EnvVar{
+			Name:  fmt.Sprintf("CAMUNDA_SECURITY_INITIALIZATION_DEFAULTROLES_ADMIN_USERS_%d", i),
+			Value: username,
+		})
+	}
+	return e
+}
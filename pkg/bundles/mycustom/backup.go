@@ -0,0 +1,109 @@
+package mycustom
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
+)
+
+const (
+	// s3AccessKey and s3SecretKey are the keys of the S3 credentials secret.
+	s3AccessKey = "accessKey"
+	s3SecretKey = "secretKey"
+
+	dataPath = "/usr/local/zeebe/data"
+)
+
+// backupEnv configures the store the brokers write their backups to and the snapshot repository
+// the web applications back up the secondary storage to.
+func backupEnv(camunda v1alpha1.OrchestrationCluster) []corev1.EnvVar {
+	store := camunda.Spec.Backup
+	if store == nil {
+		return nil
+	}
+
+	var e []corev1.EnvVar
+	if store.S3 != nil {
+		e = append(e, corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_STORE", Value: "S3"})
+		e = append(e, nonEmptyEnv(
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_S3_BUCKETNAME", Value: store.S3.BucketName},
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_S3_BASEPATH", Value: store.S3.BasePath},
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_S3_REGION", Value: store.S3.Region},
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_S3_ENDPOINT", Value: store.S3.Endpoint},
+		)...)
+		if store.S3.Endpoint != "" {
+			// S3 compatible stores usually do not support virtual hosted buckets.
+			e = append(e, corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_S3_FORCEPATHSTYLEACCESS", Value: "true"})
+		}
+		if secret := store.S3.CredentialsSecret; secret != nil {
+			e = append(e,
+				secretEnv("ZEEBE_BROKER_DATA_BACKUP_S3_ACCESSKEY", *secret, s3AccessKey),
+				secretEnv("ZEEBE_BROKER_DATA_BACKUP_S3_SECRETKEY", *secret, s3SecretKey),
+			)
+		}
+	}
+	if store.GCS != nil {
+		e = append(e, corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_STORE", Value: "GCS"})
+		e = append(e, nonEmptyEnv(
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_GCS_BUCKETNAME", Value: store.GCS.BucketName},
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_GCS_BASEPATH", Value: store.GCS.BasePath},
+			corev1.EnvVar{Name: "ZEEBE_BROKER_DATA_BACKUP_GCS_AUTH", Value: "auto"},
+		)...)
+	}
+
+	if backup.HasSecondaryStorage(camunda.Spec) && store.RepositoryName != "" {
+		e = append(e,
+			corev1.EnvVar{Name: "CAMUNDA_OPERATE_BACKUP_REPOSITORYNAME", Value: store.RepositoryName},
+			corev1.EnvVar{Name: "CAMUNDA_TASKLIST_BACKUP_REPOSITORYNAME", Value: store.RepositoryName},
+		)
+	}
+	return e
+}
+
+// restoreInitContainers returns the container restoring the data of the broker from a backup while
+// the cluster is restored. The restore only runs on an empty data volume, so a broker restarting
+// during the restore keeps the data it already restored.
+func restoreInitContainers(camunda v1alpha1.OrchestrationCluster, env []corev1.EnvVar) []corev1.Container {
+	backupID, ok := backup.RestoreBackupID(&camunda)
+	if !ok {
+		return nil
+	}
+
+	script := `if [ -z "$(ls -A ` + dataPath + ` | grep -v '^lost+found$')" ]; then ` +
+		`exec /usr/local/camunda/bin/restore --backupId=` + strconv.FormatInt(backupID, 10) + `; fi`
+	return []corev1.Container{{
+		Name:            "restore",
+		Image:           "camunda/camunda:" + camunda.Spec.Version,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/bin/sh", "-c", script},
+		Resources:       camunda.Spec.Resources,
+		SecurityContext: securityContext(),
+		Env:             env,
+		EnvFrom:         camunda.Spec.EnvFrom,
+		VolumeMounts:    createVolumeMounts(camunda),
+	}}
+}
+
+// nonEmptyEnv drops the variables without a value.
+func nonEmptyEnv(vars ...corev1.EnvVar) []corev1.EnvVar {
+	var e []corev1.EnvVar
+	for _, v := range vars {
+		if v.Value != "" {
+			e = append(e, v)
+		}
+	}
+	return e
+}
+
+func secretEnv(name string, secret corev1.LocalObjectReference, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: secret,
+			Key:                  key,
+		}},
+	}
+}
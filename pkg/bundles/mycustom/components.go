@@ -0,0 +1,78 @@
+package mycustom
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func components(camunda v1alpha1.OrchestrationCluster) v1alpha1.Components {
+	if camunda.Spec.Components == nil {
+		return v1alpha1.Components{}
+	}
+	return *camunda.Spec.Components
+}
+
+// enabled reports whether a component is enabled, components are enabled unless disabled explicitly.
+func enabled(toggle *bool) bool {
+	return ptr.Deref(toggle, true)
+}
+
+func brokerEnabled(camunda v1alpha1.OrchestrationCluster) bool {
+	return enabled(components(camunda).Broker)
+}
+
+// profiles returns the Spring profiles of the enabled components.
+func profiles(camunda v1alpha1.OrchestrationCluster) string {
+	c := components(camunda)
+	var p []string
+	if enabled(c.Identity) {
+		p = append(p, "identity")
+	}
+	if enabled(c.Operate) {
+		p = append(p, "operate")
+	}
+	if enabled(c.Tasklist) {
+		p = append(p, "tasklist")
+	}
+	if enabled(c.Broker) {
+		p = append(p, "broker")
+	}
+	return strings.Join(append(p, "consolidated-auth"), ",")
+}
+
+// componentEnv removes the settings of the disabled components, e.g. the exporters of the broker or
+// the database clients of Operate and Tasklist.
+func componentEnv(camunda v1alpha1.OrchestrationCluster, e []corev1.EnvVar) []corev1.EnvVar {
+	c := components(camunda)
+	var disabled []string
+	if !enabled(c.Broker) {
+		disabled = append(disabled, "ZEEBE_BROKER_")
+	}
+	if !enabled(c.Operate) {
+		disabled = append(disabled, "CAMUNDA_OPERATE_")
+	}
+	if !enabled(c.Tasklist) {
+		disabled = append(disabled, "CAMUNDA_TASKLIST_")
+	}
+
+	var out []corev1.EnvVar
+	for _, envVar := range e {
+		if !hasAnyPrefix(envVar.Name, disabled) {
+			out = append(out, envVar)
+		}
+	}
+	return out
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,8 +2,12 @@ package mycustom
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
 )
 
 func camundaExporterEnv(hostName, username string, password corev1.SecretKeySelector) []corev1.EnvVar {
@@ -144,3 +148,247 @@ func appDatabase(
 		},
 	}
 }
+
+// rdbmsConstraint is the range of versions that support an RDBMS as secondary storage.
+const rdbmsConstraint = ">= 8.8.0-0"
+
+// defaultPostgresqlDatabaseName is used when database.databaseName is not set.
+const defaultPostgresqlDatabaseName = "camunda"
+
+// validateDatabase rejects database types the given version cannot run with.
+func validateDatabase(version string, database v1alpha1.Database) error {
+	if database.Type != v1alpha1.PostgresqlDatabaseType {
+		return nil
+	}
+	ok, err := supportsVersion(version, rdbmsConstraint)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("database type %s requires version %s, got %s",
+			database.Type, rdbmsConstraint, version)
+	}
+	return nil
+}
+
+// supportsVersion reports whether the version satisfies the constraint.
+func supportsVersion(version, constraint string) (bool, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid version format: %s", version)
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, fmt.Errorf("invalid version constraint: %s", constraint)
+	}
+	return c.Check(v), nil
+}
+
+func postgresqlJdbcURL(database v1alpha1.Database) string {
+	name := database.DatabaseName
+	if name == "" {
+		name = defaultPostgresqlDatabaseName
+	}
+	url := fmt.Sprintf("jdbc:postgresql://%s/%s", database.HostName, name)
+	if database.TLS != nil && database.TLS.CA != nil {
+		url += "?sslmode=verify-full&sslrootcert=" + databaseCAPath
+	}
+	return url
+}
+
+// postgresqlEnv configures PostgreSQL as secondary storage and the RDBMS exporter writing to it.
+func postgresqlEnv(database v1alpha1.Database) []corev1.EnvVar {
+	url := postgresqlJdbcURL(database)
+	password := database.Password
+
+	return []corev1.EnvVar{
+		{
+			Name:  "CAMUNDA_DATABASE_TYPE",
+			Value: "rdbms",
+		},
+		{
+			Name:  "CAMUNDA_DATA_SECONDARYSTORAGE_TYPE",
+			Value: "rdbms",
+		},
+		{
+			Name:  "CAMUNDA_DATA_SECONDARYSTORAGE_RDBMS_URL",
+			Value: url,
+		},
+		{
+			Name:  "CAMUNDA_DATA_SECONDARYSTORAGE_RDBMS_USERNAME",
+			Value: database.UserName,
+		},
+		{
+			Name:      "CAMUNDA_DATA_SECONDARYSTORAGE_RDBMS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &password},
+		},
+		{
+			Name:  "SPRING_DATASOURCE_URL",
+			Value: url,
+		},
+		{
+			Name:  "SPRING_DATASOURCE_USERNAME",
+			Value: database.UserName,
+		},
+		{
+			Name:      "SPRING_DATASOURCE_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &password},
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_RDBMS_CLASSNAME",
+			Value: "io.camunda.exporter.rdbms.RdbmsExporter",
+		},
+	}
+}
+
+const (
+	defaultAWSServiceName = "es"
+	// roleARNAnnotation binds the service account to an IAM role through IRSA.
+	roleARNAnnotation = "eks.amazonaws.com/role-arn"
+)
+
+// opensearchEnv configures OpenSearch as secondary storage, the Camunda and OpenSearch exporters and
+// the Operate and Tasklist clients. With AWS authentication the credentials are optional.
+func opensearchEnv(database v1alpha1.Database) []corev1.EnvVar {
+	e := []corev1.EnvVar{
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_CLASSNAME",
+			Value: "io.camunda.exporter.CamundaExporter",
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_ARGS_CONNECT_TYPE",
+			Value: "opensearch",
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_ARGS_CONNECT_URL",
+			Value: database.HostName,
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_OPENSEARCH_CLASSNAME",
+			Value: "io.camunda.zeebe.exporter.opensearch.OpensearchExporter",
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_OPENSEARCH_ARGS_URL",
+			Value: database.HostName,
+		},
+		{
+			Name:  "CAMUNDA_DATABASE_TYPE",
+			Value: "opensearch",
+		},
+		{
+			Name:  "CAMUNDA_DATABASE_URL",
+			Value: database.HostName,
+		},
+	}
+	e = append(e, credentialsEnv("ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_ARGS_CONNECT", database)...)
+	e = append(e, credentialsEnv("ZEEBE_BROKER_EXPORTERS_OPENSEARCH_ARGS_AUTHENTICATION", database)...)
+	e = append(e, credentialsEnv("CAMUNDA_DATABASE", database)...)
+
+	for _, app := range []string{"OPERATE", "TASKLIST"} {
+		e = append(e,
+			corev1.EnvVar{Name: fmt.Sprintf("CAMUNDA_%s_DATABASE", app), Value: "opensearch"},
+			corev1.EnvVar{Name: fmt.Sprintf("CAMUNDA_%s_OPENSEARCH_URL", app), Value: database.HostName},
+			corev1.EnvVar{Name: fmt.Sprintf("CAMUNDA_%s_ZEEBEOPENSEARCH_URL", app), Value: database.HostName},
+		)
+		e = append(e, credentialsEnv(fmt.Sprintf("CAMUNDA_%s_OPENSEARCH", app), database)...)
+		e = append(e, credentialsEnv(fmt.Sprintf("CAMUNDA_%s_ZEEBEOPENSEARCH", app), database)...)
+	}
+
+	if database.AWS != nil {
+		e = append(e, awsEnv(*database.AWS)...)
+	}
+
+	return e
+}
+
+// awsEnv enables SigV4 request signing for all OpenSearch clients. The AWS SDK resolves the
+// credentials itself, so only the region and the signing name are passed.
+func awsEnv(aws v1alpha1.AWSAuthentication) []corev1.EnvVar {
+	serviceName := aws.ServiceName
+	if serviceName == "" {
+		serviceName = defaultAWSServiceName
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name:  "AWS_REGION",
+			Value: aws.Region,
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_OPENSEARCH_ARGS_AWS_ENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_OPENSEARCH_ARGS_AWS_REGION",
+			Value: aws.Region,
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_OPENSEARCH_ARGS_AWS_SERVICENAME",
+			Value: serviceName,
+		},
+		{
+			Name:  "ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_ARGS_CONNECT_AWSENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "CAMUNDA_DATABASE_AWSENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "CAMUNDA_OPERATE_OPENSEARCH_AWSENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "CAMUNDA_TASKLIST_OPENSEARCH_AWSENABLED",
+			Value: "true",
+		},
+	}
+}
+
+// credentialsEnv returns the username and password settings with the given prefix.
+// Nothing is returned when no password is referenced, e.g. when AWS authentication is used.
+func credentialsEnv(prefix string, database v1alpha1.Database) []corev1.EnvVar {
+	if database.Password.Name == "" {
+		return nil
+	}
+	password := database.Password
+
+	return []corev1.EnvVar{
+		{
+			Name:  prefix + "_USERNAME",
+			Value: database.UserName,
+		},
+		{
+			Name:      prefix + "_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &password},
+		},
+	}
+}
+
+// databaseTLSEnv points the Camunda exporter, the Camunda database client and the Operate and
+// Tasklist clients at the mounted CA. Clients without certificate settings use the JVM truststore.
+func databaseTLSEnv(database v1alpha1.Database) []corev1.EnvVar {
+	e := javaTruststoreEnv(database)
+	if database.TLS == nil || database.TLS.CA == nil || database.Type == v1alpha1.PostgresqlDatabaseType {
+		return e
+	}
+
+	e = append(e,
+		corev1.EnvVar{Name: "ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_ARGS_CONNECT_SECURITY_ENABLED", Value: "true"},
+		corev1.EnvVar{Name: "ZEEBE_BROKER_EXPORTERS_CAMUNDAEXPORTER_ARGS_CONNECT_SECURITY_CERTIFICATEPATH",
+			Value: databaseCAPath},
+		corev1.EnvVar{Name: "CAMUNDA_DATABASE_SECURITY_ENABLED", Value: "true"},
+		corev1.EnvVar{Name: "CAMUNDA_DATABASE_SECURITY_CERTIFICATEPATH", Value: databaseCAPath},
+	)
+
+	engine := strings.ToUpper(string(database.Type))
+	for _, app := range []string{"OPERATE", "TASKLIST"} {
+		e = append(e,
+			corev1.EnvVar{Name: fmt.Sprintf("CAMUNDA_%s_%s_SSL_CERTIFICATEPATH", app, engine),
+				Value: databaseCAPath},
+			corev1.EnvVar{Name: fmt.Sprintf("CAMUNDA_%s_ZEEBE%s_SSL_CERTIFICATEPATH", app, engine),
+				Value: databaseCAPath},
+		)
+	}
+	return e
+}
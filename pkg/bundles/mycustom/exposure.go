@@ -0,0 +1,186 @@
+package mycustom
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/labels"
+)
+
+// gatewayAPIVersion is the version of the Gateway API routes, HTTPRoute and GRPCRoute are GA in v1.
+const gatewayAPIVersion = "gateway.networking.k8s.io/v1"
+
+// createExposure returns the Ingresses or Gateway API routes of the exposed endpoints.
+func createExposure(camunda v1alpha1.OrchestrationCluster) []client.Object {
+	exposure := camunda.Spec.Exposure
+	if exposure == nil {
+		return nil
+	}
+
+	var objects []client.Object
+	if ingress := exposure.Ingress; ingress != nil {
+		if ingress.HTTP != nil {
+			objects = append(objects,
+				createIngress(camunda, "-http", ingress.ClassName, ingress.HTTP, httpPath(ingress.HTTP), "http"))
+		}
+		if ingress.GRPC != nil {
+			objects = append(objects, createIngress(camunda, "-grpc", ingress.ClassName, ingress.GRPC, "/", "gateway"))
+		}
+	}
+	if gatewayAPI := exposure.GatewayAPI; gatewayAPI != nil {
+		if gatewayAPI.HTTP != nil {
+			objects = append(objects, createHTTPRoute(camunda, gatewayAPI))
+		}
+		if gatewayAPI.GRPC != nil {
+			objects = append(objects, createGRPCRoute(camunda, gatewayAPI))
+		}
+	}
+	return objects
+}
+
+func createIngress(
+	camunda v1alpha1.OrchestrationCluster,
+	suffix string,
+	className *string,
+	endpoint *v1alpha1.ExposedEndpoint,
+	path, portName string,
+) *networkingv1.Ingress {
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        buildNameWithCore(camunda) + suffix,
+			Namespace:   camunda.Namespace,
+			Labels:      labels.Create(&camunda),
+			Annotations: endpoint.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: className,
+			Rules: []networkingv1.IngressRule{{
+				Host: endpoint.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: ptr.To(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: createGatewayService(camunda).Name,
+									Port: networkingv1.ServiceBackendPort{Name: portName},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if endpoint.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{
+			Hosts:      []string{endpoint.Host},
+			SecretName: endpoint.TLSSecretName,
+		}}
+	}
+	return ingress
+}
+
+func createHTTPRoute(camunda v1alpha1.OrchestrationCluster, gatewayAPI *v1alpha1.GatewayAPIExposure) client.Object {
+	route := newRoute(camunda, "HTTPRoute", "-http", gatewayAPI.ParentRefs, gatewayAPI.HTTP)
+	route.Object["spec"].(map[string]any)["rules"] = []any{
+		map[string]any{
+			"matches": []any{
+				map[string]any{
+					"path": map[string]any{"type": "PathPrefix", "value": httpPath(gatewayAPI.HTTP)},
+				},
+			},
+			"backendRefs": []any{backendRef(camunda, gatewayHTTPPort(camunda))},
+		},
+	}
+	return route
+}
+
+func createGRPCRoute(camunda v1alpha1.OrchestrationCluster, gatewayAPI *v1alpha1.GatewayAPIExposure) client.Object {
+	route := newRoute(camunda, "GRPCRoute", "-grpc", gatewayAPI.ParentRefs, gatewayAPI.GRPC)
+	route.Object["spec"].(map[string]any)["rules"] = []any{
+		map[string]any{
+			"backendRefs": []any{backendRef(camunda, gatewayGRPCPort(camunda))},
+		},
+	}
+	return route
+}
+
+// newRoute returns a route without rules. The Gateway API types are not vendored, so the routes
+// are built as unstructured objects.
+func newRoute(
+	camunda v1alpha1.OrchestrationCluster,
+	kind, suffix string,
+	parentRefs []v1alpha1.GatewayParentReference,
+	endpoint *v1alpha1.ExposedEndpoint,
+) *unstructured.Unstructured {
+	refs := make([]any, 0, len(parentRefs))
+	for _, parent := range parentRefs {
+		ref := map[string]any{"name": parent.Name}
+		if parent.Namespace != "" {
+			ref["namespace"] = parent.Namespace
+		}
+		if parent.SectionName != "" {
+			ref["sectionName"] = parent.SectionName
+		}
+		refs = append(refs, ref)
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"parentRefs": refs,
+			"hostnames":  []any{endpoint.Host},
+		},
+	}}
+	route.SetAPIVersion(gatewayAPIVersion)
+	route.SetKind(kind)
+	route.SetName(buildNameWithCore(camunda) + suffix)
+	route.SetNamespace(camunda.Namespace)
+	route.SetLabels(labels.Create(&camunda))
+	route.SetAnnotations(endpoint.Annotations)
+	return route
+}
+
+func backendRef(camunda v1alpha1.OrchestrationCluster, port int32) map[string]any {
+	return map[string]any{
+		"name": createGatewayService(camunda).Name,
+		"port": int64(port),
+	}
+}
+
+// httpPath returns the path prefix the REST API and the web applications are exposed on.
+func httpPath(endpoint *v1alpha1.ExposedEndpoint) string {
+	if endpoint.Path == "" {
+		return "/"
+	}
+	return endpoint.Path
+}
+
+// exposureEnv serves the application below the path the HTTP endpoint is exposed on.
+func exposureEnv(camunda v1alpha1.OrchestrationCluster) []corev1.EnvVar {
+	exposure := camunda.Spec.Exposure
+	if exposure == nil {
+		return nil
+	}
+	var endpoint *v1alpha1.ExposedEndpoint
+	switch {
+	case exposure.Ingress != nil:
+		endpoint = exposure.Ingress.HTTP
+	case exposure.GatewayAPI != nil:
+		endpoint = exposure.GatewayAPI.HTTP
+	}
+	if endpoint == nil || httpPath(endpoint) == "/" {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: "SERVER_SERVLET_CONTEXTPATH", Value: endpoint.Path}}
+}
@@ -0,0 +1,216 @@
+package mycustom
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/labels"
+)
+
+const (
+	// defaultGatewayReplicas is the number of standalone gateways if neither replicas nor
+	// autoscaling is configured.
+	defaultGatewayReplicas = 2
+	// defaultGatewayCPUUtilization is the CPU utilization the autoscaler aims for if no target is set.
+	defaultGatewayCPUUtilization = 80
+)
+
+// standaloneGateway reports whether the gateways run in a Deployment of their own.
+func standaloneGateway(camunda v1alpha1.OrchestrationCluster) bool {
+	return camunda.Spec.StandaloneGateway != nil
+}
+
+func gatewayName(camunda v1alpha1.OrchestrationCluster) string {
+	return camunda.Name + "-gateway"
+}
+
+func createGatewayDeployment(camunda v1alpha1.OrchestrationCluster) *appsv1.Deployment {
+	gateway := camunda.Spec.StandaloneGateway
+
+	var replicas *int32
+	if gateway.Autoscaling == nil {
+		replicas = ptr.To(ptr.Deref(gateway.Replicas, defaultGatewayReplicas))
+	}
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gatewayName(camunda),
+			Namespace: camunda.Namespace,
+			Labels:    labels.CreateGateway(&camunda),
+		},
+		Spec: appsv1.DeploymentSpec{
+			// The replicas are left to the autoscaler if it is configured.
+			Replicas: replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels.CreateGatewaySelector(&camunda),
+			},
+			Template: createGatewayPodTemplate(camunda),
+		},
+	}
+}
+
+func createGatewayPodTemplate(camunda v1alpha1.OrchestrationCluster) corev1.PodTemplateSpec {
+	volumes := []corev1.Volume{
+		{
+			Name: "tmp",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "tmp",
+			MountPath: "/tmp",
+		},
+	}
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: labels.CreateGateway(&camunda),
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext:    createPodSecurityContext(),
+			ServiceAccountName: createServiceAccount(camunda).Name,
+			NodeSelector:       camunda.Spec.NodeSelector,
+			Tolerations:        camunda.Spec.Tolerations,
+			PriorityClassName:  camunda.Spec.PriorityClassName,
+			Containers: []corev1.Container{
+				{
+					Name:            "gateway",
+					Image:           "camunda/camunda:" + camunda.Spec.Version,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Resources:       camunda.Spec.StandaloneGateway.Resources,
+					Ports:           createGatewayContainerPorts(camunda),
+					LivenessProbe:   livenessProbe(),
+					ReadinessProbe:  readinessProbe(),
+					StartupProbe:    startupProbe(),
+					SecurityContext: securityContext(),
+					Env:             mergeEnvVars(gatewayEnv(camunda), camunda.Spec.Env),
+					EnvFrom:         camunda.Spec.EnvFrom,
+					VolumeMounts:    append(mounts, databaseTLSVolumeMounts(camunda.Spec.Database)...),
+				},
+			},
+			InitContainers: truststoreInitContainers(camunda),
+			Volumes:        append(volumes, databaseTLSVolumes(camunda.Spec.Database)...),
+		},
+	}
+}
+
+// createGatewayContainerPorts returns the client ports and the port the gateway joins the cluster on.
+func createGatewayContainerPorts(camunda v1alpha1.OrchestrationCluster) []corev1.ContainerPort {
+	var ports []corev1.ContainerPort
+	for _, port := range createPorts(camunda) {
+		if port.Name != "command" {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// gatewayEnv returns the environment of the brokers without the broker settings, with the gateway
+// profile instead of the broker profile and the settings the gateway joins the cluster with.
+func gatewayEnv(camunda v1alpha1.OrchestrationCluster) []corev1.EnvVar {
+	var e []corev1.EnvVar
+	for _, envVar := range env(camunda) {
+		if strings.HasPrefix(envVar.Name, "ZEEBE_BROKER_") {
+			continue
+		}
+		if envVar.Name == "SPRING_PROFILES_ACTIVE" {
+			envVar.Value = replaceProfile(envVar.Value, "broker", "gateway")
+		}
+		e = append(e, envVar)
+	}
+
+	return append(e,
+		corev1.EnvVar{
+			Name:  "ZEEBE_GATEWAY_CLUSTER_INITIALCONTACTPOINTS",
+			Value: getPodAddresses(camunda),
+		},
+		corev1.EnvVar{
+			Name: "ZEEBE_GATEWAY_CLUSTER_MEMBERID",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"},
+			},
+		},
+		// Gateway pods have no stable DNS name, they are reached by the brokers on their pod IP.
+		corev1.EnvVar{
+			Name: "ZEEBE_GATEWAY_CLUSTER_HOST",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "status.podIP"},
+			},
+		},
+	)
+}
+
+// replaceProfile replaces a profile in a comma separated list of Spring profiles.
+func replaceProfile(profiles, old, replacement string) string {
+	list := strings.Split(profiles, ",")
+	for i, profile := range list {
+		if profile == old {
+			list[i] = replacement
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+func createGatewayAutoscaler(camunda v1alpha1.OrchestrationCluster) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := camunda.Spec.StandaloneGateway.Autoscaling
+
+	cpu := autoscaling.TargetCPUUtilizationPercentage
+	if cpu == nil && autoscaling.TargetMemoryUtilizationPercentage == nil {
+		cpu = ptr.To[int32](defaultGatewayCPUUtilization)
+	}
+	var metrics []autoscalingv2.MetricSpec
+	if cpu != nil {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceCPU, *cpu))
+	}
+	if memory := autoscaling.TargetMemoryUtilizationPercentage; memory != nil {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceMemory, *memory))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gatewayName(camunda),
+			Namespace: camunda.Namespace,
+			Labels:    labels.CreateGateway(&camunda),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       gatewayName(camunda),
+			},
+			MinReplicas: ptr.To(ptr.Deref(autoscaling.MinReplicas, defaultGatewayReplicas)),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func utilizationMetric(resource corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(utilization),
+			},
+		},
+	}
+}
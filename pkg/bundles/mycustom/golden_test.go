@@ -8,8 +8,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/backup"
 	goldens "github.com/camunda/camunda-operator/pkg/golden"
 )
 
@@ -97,3 +99,312 @@ func TestBuildAllGolden_Strategy(t *testing.T) {
 		})
 	}
 }
+
+func postgresqlSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.Database = v1alpha1.Database{
+		Type:     v1alpha1.PostgresqlDatabaseType,
+		UserName: "camunda",
+		Password: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql"},
+			Key:                  "password",
+		},
+		HostName:     "postgresql:5432",
+		DatabaseName: "orchestration",
+	}
+	return osc
+}
+
+func TestBuildAllGolden_Postgresql(t *testing.T) {
+	checkAllGolden(t, postgresqlSpec())
+}
+
+func opensearchSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.Database = v1alpha1.Database{
+		Type:     v1alpha1.OpensearchDatabaseType,
+		HostName: "https://search-camunda.eu-west-1.es.amazonaws.com",
+		AWS: &v1alpha1.AWSAuthentication{
+			Region:  "eu-west-1",
+			RoleARN: "arn:aws:iam::123456789012:role/camunda",
+		},
+	}
+	return osc
+}
+
+func TestBuildAllGolden_Opensearch(t *testing.T) {
+	checkAllGolden(t, opensearchSpec())
+}
+
+func elasticsearchTLSSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.Database.HostName = "https://elasticsearch-es-http:9200"
+	osc.Spec.Database.TLS = &v1alpha1.DatabaseTLS{
+		CA: &v1alpha1.CertificateSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "elasticsearch-es-http-certs-public"},
+				Key:                  "ca.crt",
+			},
+		},
+		ClientCertificate: &corev1.LocalObjectReference{Name: "camunda-client-cert"},
+	}
+	return osc
+}
+
+func TestBuildAllGolden_ElasticsearchTLS(t *testing.T) {
+	checkAllGolden(t, elasticsearchTLSSpec())
+}
+
+func storageSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.Storage = &v1alpha1.Storage{
+		Size:             ptr.To(resource.MustParse("50Gi")),
+		StorageClassName: ptr.To("fast-ssd"),
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+		Labels:           map[string]string{"backup": "enabled"},
+		Annotations:      map[string]string{"example.com/retain": "true"},
+	}
+	return osc
+}
+
+func TestBuildAllGolden_Storage(t *testing.T) {
+	checkAllGolden(t, storageSpec())
+}
+
+func schedulingSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.NodeSelector = map[string]string{"workload": "camunda"}
+	osc.Spec.Tolerations = []corev1.Toleration{{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "camunda",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}}
+	osc.Spec.Affinity = &corev1.Affinity{}
+	osc.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelTopologyZone,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}}
+	osc.Spec.PriorityClassName = "camunda-critical"
+	return osc
+}
+
+func TestBuildAllGolden_Scheduling(t *testing.T) {
+	checkAllGolden(t, schedulingSpec())
+}
+
+func backupSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.Backup = &v1alpha1.BackupStore{
+		RepositoryName: "camunda-backups",
+		S3: &v1alpha1.S3BackupStore{
+			BucketName:        "camunda-backups",
+			BasePath:          "camunda-orchestration",
+			Endpoint:          "http://minio:9000",
+			CredentialsSecret: &corev1.LocalObjectReference{Name: "minio-credentials"},
+		},
+	}
+	return osc
+}
+
+func TestBuildAllGolden_Backup(t *testing.T) {
+	checkAllGolden(t, backupSpec())
+}
+
+func TestBuildAllGolden_Restore(t *testing.T) {
+	osc := backupSpec()
+	osc.Annotations = map[string]string{backup.RestoreAnnotation: "100"}
+	checkAllGolden(t, osc)
+}
+
+func ingressSpec() v1alpha1.OrchestrationCluster {
+	osc := apiSpec()
+	osc.Spec.Exposure = &v1alpha1.Exposure{
+		Ingress: &v1alpha1.IngressExposure{
+			ClassName: ptr.To("nginx"),
+			HTTP: &v1alpha1.ExposedEndpoint{
+				Host:          "camunda.example.com",
+				Path:          "/orchestration",
+				TLSSecretName: "camunda-tls",
+				Annotations:   map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"},
+			},
+			GRPC: &v1alpha1.ExposedEndpoint{
+				Host:          "zeebe.example.com",
+				TLSSecretName: "zeebe-tls",
+				Annotations:   map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"},
+			},
+		},
+	}
+	return osc
+}
+
+func TestBuildAllGolden_Ingress(t *testing.T) {
+	checkAllGolden(t, ingressSpec())
+}
+
+func TestBuildAllGolden_GatewayAPI(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Exposure = &v1alpha1.Exposure{
+		GatewayAPI: &v1alpha1.GatewayAPIExposure{
+			ParentRefs: []v1alpha1.GatewayParentReference{{
+				Name:        "public",
+				Namespace:   "gateways",
+				SectionName: "https",
+			}},
+			HTTP: &v1alpha1.ExposedEndpoint{Host: "camunda.example.com"},
+			GRPC: &v1alpha1.ExposedEndpoint{Host: "zeebe.example.com"},
+		},
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_GatewayService(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.GatewayService = &v1alpha1.GatewayService{
+		Type: corev1.ServiceTypeLoadBalancer,
+		Annotations: map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal",
+		},
+		ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+		LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+		PublishNotReadyAddresses: ptr.To(false),
+		HTTP:                     &v1alpha1.GatewayServicePort{Port: 80},
+		GRPC:                     &v1alpha1.GatewayServicePort{Port: 443, NodePort: 30443},
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_StandaloneGateway(t *testing.T) {
+	osc := elasticsearchTLSSpec()
+	osc.Spec.StandaloneGateway = &v1alpha1.StandaloneGateway{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
+		Autoscaling: &v1alpha1.GatewayAutoscaling{
+			MaxReplicas:                       5,
+			TargetMemoryUtilizationPercentage: ptr.To[int32](70),
+		},
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_ServiceMonitor(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.StandaloneGateway = &v1alpha1.StandaloneGateway{}
+	osc.Spec.Monitoring = &v1alpha1.Monitoring{
+		Kind:     v1alpha1.ServiceMonitorKind,
+		Interval: "30s",
+		Labels:   map[string]string{"release": "kube-prometheus-stack"},
+		Relabelings: []v1alpha1.RelabelConfig{{
+			SourceLabels: []string{"__meta_kubernetes_pod_node_name"},
+			TargetLabel:  "node",
+		}},
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_PodMonitor(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Monitoring = &v1alpha1.Monitoring{
+		Kind:          v1alpha1.PodMonitorKind,
+		ScrapeTimeout: "10s",
+		MetricRelabelings: []v1alpha1.RelabelConfig{{
+			SourceLabels: []string{"__name__"},
+			Regex:        "jvm_.*",
+			Action:       "drop",
+		}},
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_BrokerOnly(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Components = &v1alpha1.Components{
+		Operate:  ptr.To(false),
+		Tasklist: ptr.To(false),
+		Identity: ptr.To(false),
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_WebappsOnly(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Components = &v1alpha1.Components{Broker: ptr.To(false)}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_BasicAuthentication(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Authentication = &v1alpha1.Authentication{
+		Basic: &v1alpha1.BasicAuthentication{
+			AdminSecret: corev1.LocalObjectReference{Name: "camunda-admin"},
+			Name:        "Demo",
+			Email:       "demo@example.com",
+		},
+	}
+	checkAllGolden(t, osc)
+}
+
+func TestBuildAllGolden_OIDCAuthentication(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Authentication = &v1alpha1.Authentication{
+		OIDC: &v1alpha1.OIDCAuthentication{
+			IssuerURL: "https://keycloak.example.com/realms/camunda",
+			ClientID:  "orchestration",
+			ClientSecret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "orchestration-oidc"},
+				Key:                  "client-secret",
+			},
+			Audiences:     []string{"orchestration-api"},
+			RedirectURI:   "https://camunda.example.com/sso-callback",
+			UsernameClaim: "preferred_username",
+			GroupsClaim:   "groups",
+			AdminUsers:    []string{"alice", "bob"},
+		},
+		Authorizations: ptr.To(false),
+	}
+	checkAllGolden(t, osc)
+}
+
+func checkAllGolden(t *testing.T, osc v1alpha1.OrchestrationCluster) {
+	t.Helper()
+	m, err := Strategy{}.BuildResources(osc)
+	require.NoError(t, err)
+
+	for _, object := range m {
+		t.Run(object.GetName(), func(t *testing.T) {
+			golden, err := goldens.New(t, object.GetObjectKind().GroupVersionKind().Kind+"-"+object.GetName())
+			if err != nil {
+				t.Error("unable to create golden file", err)
+			}
+
+			err = golden.CheckOrUpdate(*update, object)
+			if err != nil {
+				t.Errorf("%s:\nerr:\n%v", osc.Name, err)
+			}
+		})
+	}
+}
+
+func TestBuildResources_PostgresqlUnsupportedVersion(t *testing.T) {
+	osc := postgresqlSpec()
+	osc.Spec.Version = "8.7.7"
+
+	_, err := Strategy{}.BuildResources(osc)
+	require.ErrorContains(t, err, "database type postgresql requires version >= 8.8.0-0, got 8.7.7")
+}
+
+func TestBuildResources_AuthenticationUnsupportedVersion(t *testing.T) {
+	osc := apiSpec()
+	osc.Spec.Version = "8.7.7"
+	osc.Spec.Authentication = &v1alpha1.Authentication{
+		Basic: &v1alpha1.BasicAuthentication{AdminSecret: corev1.LocalObjectReference{Name: "camunda-admin"}},
+	}
+
+	_, err := Strategy{}.BuildResources(osc)
+	require.ErrorContains(t, err, "authentication requires version >= 8.8.0-0, got 8.7.7")
+}
@@ -0,0 +1,125 @@
+package mycustom
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sLabels "k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/labels"
+)
+
+// monitoringAPIVersion is the version of the Prometheus Operator resources.
+const monitoringAPIVersion = "monitoring.coreos.com/v1"
+
+// metricsPath is the path the brokers and gateways serve their Prometheus metrics on.
+const metricsPath = "/actuator/prometheus"
+
+// createMonitor returns the ServiceMonitor or PodMonitor of the cluster. The Prometheus Operator
+// types are not vendored, so the monitors are built as unstructured objects.
+func createMonitor(camunda v1alpha1.OrchestrationCluster) client.Object {
+	monitoring := camunda.Spec.Monitoring
+	if monitoring == nil {
+		return nil
+	}
+
+	kind := monitoring.Kind
+	if kind == "" {
+		kind = v1alpha1.ServiceMonitorKind
+	}
+
+	endpoint := map[string]any{
+		"port": "management",
+		"path": metricsPath,
+	}
+	if monitoring.Interval != "" {
+		endpoint["interval"] = monitoring.Interval
+	}
+	if monitoring.ScrapeTimeout != "" {
+		endpoint["scrapeTimeout"] = monitoring.ScrapeTimeout
+	}
+	if len(monitoring.MetricRelabelings) > 0 {
+		endpoint["metricRelabelings"] = relabelings(monitoring.MetricRelabelings)
+	}
+
+	var spec map[string]any
+	switch kind {
+	case v1alpha1.PodMonitorKind:
+		if len(monitoring.Relabelings) > 0 {
+			endpoint["relabelings"] = relabelings(monitoring.Relabelings)
+		}
+		spec = map[string]any{
+			"selector":            map[string]any{"matchLabels": stringMap(labels.CreateClusterSelector(&camunda))},
+			"podMetricsEndpoints": []any{endpoint},
+		}
+	default:
+		// The Services of the brokers carry the same labels, so only the endpoints of the Services
+		// that select each pod once are kept: the headless Service of the brokers and the gateway
+		// Service of the standalone gateways, which carries the component of the gateways.
+		services := createHeadlessService(camunda).Name
+		if standaloneGateway(camunda) {
+			services += "|" + createGatewayService(camunda).Name
+		}
+		keepServices := map[string]any{
+			"sourceLabels": []any{"__meta_kubernetes_service_name"},
+			"regex":        services,
+			"action":       "keep",
+		}
+		endpoint["relabelings"] = append([]any{keepServices}, relabelings(monitoring.Relabelings)...)
+		spec = map[string]any{
+			"selector":  map[string]any{"matchLabels": stringMap(labels.CreateClusterSelector(&camunda))},
+			"endpoints": []any{endpoint},
+		}
+	}
+
+	monitor := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	monitor.SetAPIVersion(monitoringAPIVersion)
+	monitor.SetKind(string(kind))
+	monitor.SetName(buildNameWithCore(camunda))
+	monitor.SetNamespace(camunda.Namespace)
+	monitor.SetLabels(k8sLabels.Merge(monitoring.Labels, labels.Create(&camunda)))
+	return monitor
+}
+
+// relabelings converts the relabeling rules to the fields of the Prometheus Operator resources.
+func relabelings(configs []v1alpha1.RelabelConfig) []any {
+	rules := make([]any, 0, len(configs))
+	for _, config := range configs {
+		rule := map[string]any{}
+		if len(config.SourceLabels) > 0 {
+			sourceLabels := make([]any, 0, len(config.SourceLabels))
+			for _, label := range config.SourceLabels {
+				sourceLabels = append(sourceLabels, label)
+			}
+			rule["sourceLabels"] = sourceLabels
+		}
+		if config.Separator != "" {
+			rule["separator"] = config.Separator
+		}
+		if config.TargetLabel != "" {
+			rule["targetLabel"] = config.TargetLabel
+		}
+		if config.Regex != "" {
+			rule["regex"] = config.Regex
+		}
+		if config.Replacement != nil {
+			rule["replacement"] = *config.Replacement
+		}
+		if config.Action != "" {
+			rule["action"] = config.Action
+		}
+		if config.Modulus != 0 {
+			rule["modulus"] = int64(config.Modulus)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func stringMap(m map[string]string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
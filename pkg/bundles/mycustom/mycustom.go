@@ -6,24 +6,42 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/camunda/camunda-operator/api/v1alpha1"
 	"github.com/camunda/camunda-operator/pkg/labels"
+	"github.com/camunda/camunda-operator/pkg/storage"
 )
 
 type Strategy struct{}
 
 func (m Strategy) BuildResources(osc v1alpha1.OrchestrationCluster) ([]client.Object, error) {
+	if err := validateDatabase(osc.Spec.Version, osc.Spec.Database); err != nil {
+		return nil, err
+	}
+	if err := validateAuthentication(osc.Spec.Version, osc.Spec.Authentication); err != nil {
+		return nil, err
+	}
+
 	svcAcc := createServiceAccount(osc)
 	headlessSvc := createHeadlessService(osc)
 	gatewaySvc := createGatewayService(osc)
 	sts := createCamundaStatefulSet(osc)
+	pdb := createPodDisruptionBudget(osc)
 
-	resources := []client.Object{svcAcc, headlessSvc, gatewaySvc, sts}
+	resources := []client.Object{svcAcc, headlessSvc, gatewaySvc, sts, pdb}
+	if standaloneGateway(osc) {
+		resources = append(resources, createGatewayDeployment(osc))
+		if osc.Spec.StandaloneGateway.Autoscaling != nil {
+			resources = append(resources, createGatewayAutoscaler(osc))
+		}
+	}
+	resources = append(resources, createExposure(osc)...)
+	if monitor := createMonitor(osc); monitor != nil {
+		resources = append(resources, monitor)
+	}
 	return resources, nil
 }
 
@@ -48,7 +66,7 @@ func createCamundaStatefulSet(
 				MatchLabels: labels.CreateSelector(&camunda),
 			},
 			Template:             createPodTemplate(camunda),
-			VolumeClaimTemplates: createVolumeClaimTemplates(),
+			VolumeClaimTemplates: createVolumeClaimTemplates(camunda),
 		},
 	}
 }
@@ -61,31 +79,37 @@ func createPodTemplate(camunda v1alpha1.OrchestrationCluster) corev1.PodTemplate
 			Labels: labels.Create(&camunda),
 		},
 		Spec: corev1.PodSpec{
-			SecurityContext:    createPodSecurityContext(),
-			ServiceAccountName: createServiceAccount(camunda).Name,
+			SecurityContext:           createPodSecurityContext(),
+			ServiceAccountName:        createServiceAccount(camunda).Name,
+			NodeSelector:              camunda.Spec.NodeSelector,
+			Affinity:                  affinity(camunda),
+			Tolerations:               camunda.Spec.Tolerations,
+			TopologySpreadConstraints: topologySpreadConstraints(camunda),
+			PriorityClassName:         camunda.Spec.PriorityClassName,
 			Containers: []corev1.Container{
 				{
 					Name:            "camunda",
 					Image:           "camunda/camunda:" + camunda.Spec.Version,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Resources:       camunda.Spec.Resources,
-					Ports:           createPorts(),
+					Ports:           createPorts(camunda),
 					LivenessProbe:   livenessProbe(),
 					ReadinessProbe:  readinessProbe(),
 					StartupProbe:    startupProbe(),
 					SecurityContext: securityContext(),
 					Env:             fullEnv,
 					EnvFrom:         camunda.Spec.EnvFrom,
-					VolumeMounts:    createVolumeMounts(),
+					VolumeMounts:    createVolumeMounts(camunda),
 				},
 			},
-			Volumes: createVolumes(),
+			InitContainers: append(truststoreInitContainers(camunda), restoreInitContainers(camunda, fullEnv)...),
+			Volumes:        createVolumes(camunda),
 		},
 	}
 }
 
-func createVolumes() []corev1.Volume {
-	return []corev1.Volume{
+func createVolumes(camunda v1alpha1.OrchestrationCluster) []corev1.Volume {
+	volumes := []corev1.Volume{
 		{
 			Name: "tmp",
 			VolumeSource: corev1.VolumeSource{
@@ -99,13 +123,14 @@ func createVolumes() []corev1.Volume {
 			},
 		},
 	}
+	return append(volumes, databaseTLSVolumes(camunda.Spec.Database)...)
 }
 
-func createVolumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
+func createVolumeMounts(camunda v1alpha1.OrchestrationCluster) []corev1.VolumeMount {
+	mounts := []corev1.VolumeMount{
 		{
-			Name:      "data",
-			MountPath: "/usr/local/zeebe/data",
+			Name:      storage.VolumeName,
+			MountPath: dataPath,
 		},
 		{
 			Name:      "exporters",
@@ -116,24 +141,30 @@ func createVolumeMounts() []corev1.VolumeMount {
 			MountPath: "/tmp",
 		},
 	}
+	return append(mounts, databaseTLSVolumeMounts(camunda.Spec.Database)...)
 }
 
-func createVolumeClaimTemplates() []corev1.PersistentVolumeClaim {
-	return []corev1.PersistentVolumeClaim{
-		{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "data",
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse("10Gi"),
-					},
+func createVolumeClaimTemplates(camunda v1alpha1.OrchestrationCluster) []corev1.PersistentVolumeClaim {
+	claim := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: storage.VolumeName,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: storage.AccessModes(&camunda),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage.Size(&camunda),
 				},
 			},
 		},
 	}
+	if camunda.Spec.Storage != nil {
+		claim.Labels = camunda.Spec.Storage.Labels
+		claim.Annotations = camunda.Spec.Storage.Annotations
+		claim.Spec.StorageClassName = camunda.Spec.Storage.StorageClassName
+	}
+
+	return []corev1.PersistentVolumeClaim{claim}
 }
 
 func securityContext() *corev1.SecurityContext {
@@ -180,21 +211,14 @@ func env(camunda v1alpha1.OrchestrationCluster) []corev1.EnvVar {
 		},
 		{
 			Name:  "ZEEBE_BROKER_CLUSTER_CLUSTER_SIZE",
-			Value: strconv.Itoa(int(camunda.Spec.ClusterSize)),
+			Value: strconv.Itoa(int(bootstrapClusterSize(camunda))),
 		},
 		{
 			Name:  "SPRING_PROFILES_ACTIVE",
-			Value: "identity,operate,tasklist,broker,consolidated-auth",
-		},
-		{
-			Name:  "CAMUNDA_SECURITY_AUTHORIZATIONS_ENABLED",
-			Value: "true",
-		},
-		{
-			Name:  "CAMUNDA_SECURITY_AUTHENTICATION_UNPROTECTEDAPI",
-			Value: "false",
+			Value: profiles(camunda),
 		},
 	}
+	e = append(e, authenticationEnv(camunda)...)
 
 	if camunda.Spec.Database.Type == v1alpha1.ElasticsearchDatabaseType {
 		e = append(e, camundaExporterEnv(
@@ -232,7 +256,23 @@ func env(camunda v1alpha1.OrchestrationCluster) []corev1.EnvVar {
 		)...)
 	}
 
-	return e
+	if camunda.Spec.Database.Type == v1alpha1.OpensearchDatabaseType {
+		e = append(e, opensearchEnv(camunda.Spec.Database)...)
+	}
+
+	if camunda.Spec.Database.Type == v1alpha1.PostgresqlDatabaseType {
+		e = append(e, postgresqlEnv(camunda.Spec.Database)...)
+	}
+
+	if standaloneGateway(camunda) {
+		e = append(e, corev1.EnvVar{Name: "ZEEBE_BROKER_GATEWAY_ENABLE", Value: "false"})
+	}
+
+	e = append(e, databaseTLSEnv(camunda.Spec.Database)...)
+	e = append(e, backupEnv(camunda)...)
+	e = append(e, exposureEnv(camunda)...)
+
+	return componentEnv(camunda, e)
 }
 
 func buildNameWithCore(camunda v1alpha1.OrchestrationCluster) string {
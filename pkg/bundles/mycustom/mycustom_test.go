@@ -5,6 +5,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
 )
 
 func TestMergeEnvVars(t *testing.T) {
@@ -124,3 +128,124 @@ func TestMergeEnvVars(t *testing.T) {
 		assert.Equal(t, "VAR2", result[1].Name)
 	})
 }
+
+func TestPostgresqlJdbcURL(t *testing.T) {
+	t.Run("defaults the database name", func(t *testing.T) {
+		url := postgresqlJdbcURL(v1alpha1.Database{HostName: "postgresql:5432"})
+
+		assert.Equal(t, "jdbc:postgresql://postgresql:5432/camunda", url)
+	})
+
+	t.Run("uses the configured database name", func(t *testing.T) {
+		url := postgresqlJdbcURL(v1alpha1.Database{HostName: "postgresql", DatabaseName: "orchestration"})
+
+		assert.Equal(t, "jdbc:postgresql://postgresql/orchestration", url)
+	})
+
+	t.Run("verifies the server against the CA", func(t *testing.T) {
+		url := postgresqlJdbcURL(v1alpha1.Database{
+			HostName: "postgresql",
+			TLS: &v1alpha1.DatabaseTLS{CA: &v1alpha1.CertificateSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql-ca"},
+					Key:                  "ca.crt",
+				},
+			}},
+		})
+
+		assert.Equal(t,
+			"jdbc:postgresql://postgresql/camunda?sslmode=verify-full&sslrootcert=/usr/local/camunda/database-tls/ca.crt",
+			url)
+	})
+}
+
+func TestTruststoreInitContainers(t *testing.T) {
+	t.Run("not needed for postgresql", func(t *testing.T) {
+		osc := postgresqlSpec()
+		osc.Spec.Database.TLS = &v1alpha1.DatabaseTLS{CA: &v1alpha1.CertificateSource{
+			SecretKeyRef: &corev1.SecretKeySelector{Key: "ca.crt"},
+		}}
+
+		assert.Empty(t, truststoreInitContainers(osc))
+		assert.Empty(t, javaTruststoreEnv(osc.Spec.Database))
+		assert.Len(t, databaseTLSVolumes(osc.Spec.Database), 1, "Only the certificates should be mounted")
+	})
+
+	t.Run("not needed without tls", func(t *testing.T) {
+		assert.Empty(t, truststoreInitContainers(apiSpec()))
+		assert.Empty(t, databaseTLSVolumes(apiSpec().Spec.Database))
+	})
+}
+
+func TestCredentialsEnv(t *testing.T) {
+	t.Run("without password", func(t *testing.T) {
+		env := credentialsEnv("CAMUNDA_DATABASE", v1alpha1.Database{UserName: "camunda"})
+
+		assert.Empty(t, env, "Credentials should be omitted when no password is referenced")
+	})
+
+	t.Run("with password", func(t *testing.T) {
+		env := credentialsEnv("CAMUNDA_DATABASE", v1alpha1.Database{
+			UserName: "camunda",
+			Password: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "opensearch"},
+				Key:                  "password",
+			},
+		})
+
+		assert.Len(t, env, 2)
+		assert.Equal(t, "CAMUNDA_DATABASE_USERNAME", env[0].Name)
+		assert.Equal(t, "camunda", env[0].Value)
+		assert.Equal(t, "CAMUNDA_DATABASE_PASSWORD", env[1].Name)
+		assert.Equal(t, "opensearch", env[1].ValueFrom.SecretKeyRef.Name)
+	})
+}
+
+func TestAWSEnv_DefaultsServiceName(t *testing.T) {
+	env := awsEnv(v1alpha1.AWSAuthentication{Region: "eu-west-1"})
+
+	assert.Contains(t, env, corev1.EnvVar{Name: "ZEEBE_BROKER_EXPORTERS_OPENSEARCH_ARGS_AWS_SERVICENAME", Value: "es"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "AWS_REGION", Value: "eu-west-1"})
+}
+
+func TestMaxUnavailable(t *testing.T) {
+	tests := []struct {
+		replicationFactor int32
+		expected          int32
+	}{
+		{replicationFactor: 1, expected: 0},
+		{replicationFactor: 2, expected: 0},
+		{replicationFactor: 3, expected: 1},
+		{replicationFactor: 4, expected: 1},
+		{replicationFactor: 5, expected: 2},
+	}
+
+	for _, tt := range tests {
+		osc := apiSpec()
+		osc.Spec.ReplicationFactor = tt.replicationFactor
+
+		assert.Equal(t, intstr.FromInt32(tt.expected), *maxUnavailable(osc),
+			"replication factor %d", tt.replicationFactor)
+	}
+
+	t.Run("override", func(t *testing.T) {
+		osc := apiSpec()
+		osc.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromString("50%"))}
+
+		assert.Equal(t, intstr.FromString("50%"), *maxUnavailable(osc))
+	})
+}
+
+func TestCreatePodTemplate_ClusterSizeChangeKeepsTemplate(t *testing.T) {
+	bootstrapped := apiSpec()
+	bootstrapped.Spec.StandaloneGateway = &v1alpha1.StandaloneGateway{}
+	bootstrapped.Status.BootstrapClusterSize = 3
+
+	scaled := bootstrapped.DeepCopy()
+	scaled.Spec.ClusterSize = 5
+
+	assert.Equal(t, createPodTemplate(bootstrapped), createPodTemplate(*scaled),
+		"changing the cluster size must not roll the brokers")
+	assert.Equal(t, createGatewayPodTemplate(bootstrapped), createGatewayPodTemplate(*scaled),
+		"changing the cluster size must not roll the gateways")
+}
@@ -0,0 +1,43 @@
+package mycustom
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/labels"
+)
+
+func createPodDisruptionBudget(camunda v1alpha1.OrchestrationCluster) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      camunda.Name,
+			Namespace: camunda.Namespace,
+			Labels:    labels.Create(&camunda),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: maxUnavailable(camunda),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels.CreateSelector(&camunda),
+			},
+		},
+	}
+}
+
+// maxUnavailable returns the configured override or the number of replicas a partition can lose
+// while keeping its quorum. Every broker may replicate every partition, so this is also the number
+// of brokers that may be evicted at the same time.
+func maxUnavailable(camunda v1alpha1.OrchestrationCluster) *intstr.IntOrString {
+	if pdb := camunda.Spec.PodDisruptionBudget; pdb != nil && pdb.MaxUnavailable != nil {
+		return pdb.MaxUnavailable
+	}
+	quorum := camunda.Spec.ReplicationFactor/2 + 1
+	tolerated := max(camunda.Spec.ReplicationFactor-quorum, 0)
+	return ptr.To(intstr.FromInt32(tolerated))
+}
@@ -0,0 +1,51 @@
+package mycustom
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/labels"
+)
+
+// antiAffinityWeight is the weight of the default preference to run brokers on different nodes.
+const antiAffinityWeight = 100
+
+// affinity returns the configured affinity or a preferred pod anti-affinity, so brokers replicating
+// the same partitions do not share a node if the cluster has enough of them.
+func affinity(camunda v1alpha1.OrchestrationCluster) *corev1.Affinity {
+	if camunda.Spec.Affinity != nil {
+		return camunda.Spec.Affinity
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: antiAffinityWeight,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: labels.CreateSelector(&camunda)},
+						TopologyKey:   corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
+// topologySpreadConstraints returns the configured constraints or spreads the brokers evenly across
+// zones. The default does not block scheduling in clusters with fewer zones than brokers.
+func topologySpreadConstraints(camunda v1alpha1.OrchestrationCluster) []corev1.TopologySpreadConstraint {
+	if len(camunda.Spec.TopologySpreadConstraints) > 0 {
+		return camunda.Spec.TopologySpreadConstraints
+	}
+
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: labels.CreateSelector(&camunda)},
+		},
+	}
+}
@@ -9,15 +9,21 @@ import (
 )
 
 func createServiceAccount(camunda v1alpha1.OrchestrationCluster) *corev1.ServiceAccount {
+	var annotations map[string]string
+	if aws := camunda.Spec.Database.AWS; aws != nil && aws.RoleARN != "" {
+		annotations = map[string]string{roleARNAnnotation: aws.RoleARN}
+	}
+
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ServiceAccount",
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      buildNameWithCore(camunda),
-			Namespace: camunda.Namespace,
-			Labels:    labels.Create(&camunda),
+			Name:        buildNameWithCore(camunda),
+			Namespace:   camunda.Namespace,
+			Labels:      labels.Create(&camunda),
+			Annotations: annotations,
 		},
 	}
 }
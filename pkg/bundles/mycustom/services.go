@@ -12,6 +12,12 @@ import (
 	"github.com/camunda/camunda-operator/pkg/labels"
 )
 
+// HeadlessServiceName returns the name of the headless Service of the brokers, which also serves
+// their management API.
+func HeadlessServiceName(camunda v1alpha1.OrchestrationCluster) string {
+	return buildNameWithCore(camunda) + "-headless"
+}
+
 func createHeadlessService(camunda v1alpha1.OrchestrationCluster) *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -19,44 +25,55 @@ func createHeadlessService(camunda v1alpha1.OrchestrationCluster) *corev1.Servic
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      buildNameWithCore(camunda) + "-headless",
+			Name:      HeadlessServiceName(camunda),
 			Namespace: camunda.Namespace,
 			Labels:    labels.Create(&camunda),
 		},
 		Spec: corev1.ServiceSpec{
 			ClusterIP:                "None",
 			Selector:                 labels.CreateSelector(&camunda),
-			Ports:                    createHeadlessServicePorts(),
+			Ports:                    createHeadlessServicePorts(camunda),
 			PublishNotReadyAddresses: true,
 		},
 	}
 }
 
-func createHeadlessServicePorts() []corev1.ServicePort {
-	return []corev1.ServicePort{
+func createHeadlessServicePorts(camunda v1alpha1.OrchestrationCluster) []corev1.ServicePort {
+	ports := []corev1.ServicePort{
 		{
 			Name: "management",
 			Port: 9600,
 		},
-		{
+	}
+	if !brokerEnabled(camunda) {
+		return ports
+	}
+	return append(ports,
+		corev1.ServicePort{
 			Name: "command",
 			Port: 26501,
 		},
-		{
+		corev1.ServicePort{
 			Name: "internal",
 			Port: 26502,
 		},
-	}
+	)
+}
+
+// GatewayServiceName returns the name of the Service that serves the REST and gRPC API of the
+// cluster, through the standalone gateways if they are enabled.
+func GatewayServiceName(camunda v1alpha1.OrchestrationCluster) string {
+	return buildNameWithCore(camunda) + "-gateway"
 }
 
 func createGatewayService(camunda v1alpha1.OrchestrationCluster) *corev1.Service {
-	return &corev1.Service{
+	svc := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Service",
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      buildNameWithCore(camunda) + "-gateway",
+			Name:      GatewayServiceName(camunda),
 			Namespace: camunda.Namespace,
 			Labels:    labels.Create(&camunda),
 		},
@@ -64,30 +81,88 @@ func createGatewayService(camunda v1alpha1.OrchestrationCluster) *corev1.Service
 			PublishNotReadyAddresses: true,
 			Type:                     corev1.ServiceTypeClusterIP,
 			Selector:                 labels.CreateSelector(&camunda),
-			Ports:                    createGatewayPorts(),
+			Ports:                    createGatewayPorts(camunda),
 		},
 	}
+
+	// The standalone gateways serve the clients instead of the gateways embedded in the brokers.
+	if standaloneGateway(camunda) {
+		svc.Labels = labels.CreateGateway(&camunda)
+		svc.Spec.Selector = labels.CreateGatewaySelector(&camunda)
+	}
+
+	if options := camunda.Spec.GatewayService; options != nil {
+		svc.Annotations = options.Annotations
+		if options.Type != "" {
+			svc.Spec.Type = options.Type
+		}
+		svc.Spec.ExternalTrafficPolicy = options.ExternalTrafficPolicy
+		svc.Spec.LoadBalancerSourceRanges = options.LoadBalancerSourceRanges
+		if options.PublishNotReadyAddresses != nil {
+			svc.Spec.PublishNotReadyAddresses = *options.PublishNotReadyAddresses
+		}
+	}
+	return svc
 }
 
-func createGatewayPorts() []corev1.ServicePort {
-	return []corev1.ServicePort{
-		{
-			Name: "http",
-			Port: 8080,
-		},
+func createGatewayPorts(camunda v1alpha1.OrchestrationCluster) []corev1.ServicePort {
+	var httpOptions, grpcOptions *v1alpha1.GatewayServicePort
+	if options := camunda.Spec.GatewayService; options != nil {
+		httpOptions, grpcOptions = options.HTTP, options.GRPC
+	}
+
+	ports := []corev1.ServicePort{
 		{
-			Name: "management",
-			Port: 9600,
+			Name:       "http",
+			Port:       gatewayHTTPPort(camunda),
+			TargetPort: intstr.FromString("http"),
+			NodePort:   nodePort(httpOptions),
 		},
 		{
-			Name: "gateway",
-			Port: 26500,
+			Name:       "management",
+			Port:       9600,
+			TargetPort: intstr.FromString("management"),
 		},
 	}
+	// The gRPC API is served by the gateway embedded in the broker.
+	if !brokerEnabled(camunda) {
+		return ports
+	}
+	return append(ports, corev1.ServicePort{
+		Name:       "gateway",
+		Port:       gatewayGRPCPort(camunda),
+		TargetPort: intstr.FromString("gateway"),
+		NodePort:   nodePort(grpcOptions),
+	})
+}
+
+// gatewayHTTPPort returns the port of the REST API and the web applications on the gateway Service.
+func gatewayHTTPPort(camunda v1alpha1.OrchestrationCluster) int32 {
+	if options := camunda.Spec.GatewayService; options != nil && options.HTTP != nil && options.HTTP.Port != 0 {
+		return options.HTTP.Port
+	}
+	return 8080
+}
+
+// gatewayGRPCPort returns the port of the gRPC API on the gateway Service.
+func gatewayGRPCPort(camunda v1alpha1.OrchestrationCluster) int32 {
+	if options := camunda.Spec.GatewayService; options != nil && options.GRPC != nil && options.GRPC.Port != 0 {
+		return options.GRPC.Port
+	}
+	return 26500
+}
+
+func nodePort(options *v1alpha1.GatewayServicePort) int32 {
+	if options == nil {
+		return 0
+	}
+	return options.NodePort
 }
 
-func createPorts() []corev1.ContainerPort {
-	return []corev1.ContainerPort{
+// createPorts returns the ports of the enabled components. The REST API, the web applications and
+// the management API are always served.
+func createPorts(camunda v1alpha1.OrchestrationCluster) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{
 		{
 			Name:          "http",
 			ContainerPort: 8080,
@@ -96,26 +171,32 @@ func createPorts() []corev1.ContainerPort {
 			Name:          "management",
 			ContainerPort: 9600,
 		},
-		{
+	}
+	if !brokerEnabled(camunda) {
+		return ports
+	}
+	return append(ports,
+		corev1.ContainerPort{
 			Name:          "gateway",
 			ContainerPort: 26500,
 		},
-		{
+		corev1.ContainerPort{
 			Name:          "command",
 			ContainerPort: 26501,
 		},
-		{
+		corev1.ContainerPort{
 			Name:          "internal",
 			ContainerPort: 26502,
 		},
-	}
+	)
 }
 
 func getPodAddresses(camunda v1alpha1.OrchestrationCluster) string {
-	podAddresses := make([]string, camunda.Spec.ClusterSize)
+	size := bootstrapClusterSize(camunda)
+	podAddresses := make([]string, size)
 	svc := createHeadlessService(camunda)
 
-	for podIndex := int32(0); podIndex < camunda.Spec.ClusterSize; podIndex++ {
+	for podIndex := int32(0); podIndex < size; podIndex++ {
 		podAddresses[podIndex] = fmt.Sprintf(
 			"%s-%d.%s.%s.svc.cluster.local:26502",
 			camunda.Name,
@@ -127,6 +208,16 @@ func getPodAddresses(camunda v1alpha1.OrchestrationCluster) string {
 	return strings.Join(podAddresses, ",")
 }
 
+// bootstrapClusterSize is the cluster size the brokers are configured with. It stays at the size
+// the cluster was created with, so a change of spec.clusterSize does not roll the pods while the
+// scaling operation changes the topology.
+func bootstrapClusterSize(camunda v1alpha1.OrchestrationCluster) int32 {
+	if camunda.Status.BootstrapClusterSize > 0 {
+		return camunda.Status.BootstrapClusterSize
+	}
+	return camunda.Spec.ClusterSize
+}
+
 func livenessProbe() *corev1.Probe {
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
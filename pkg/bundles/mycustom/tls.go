@@ -0,0 +1,155 @@
+package mycustom
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+const (
+	databaseTLSVolume = "database-tls"
+	databaseTLSPath   = "/usr/local/camunda/database-tls"
+	databaseCAPath    = databaseTLSPath + "/ca.crt"
+	clientCertPath    = databaseTLSPath + "/tls.crt"
+	clientKeyPath     = databaseTLSPath + "/tls.key"
+
+	truststoreVolume = "truststore"
+	truststoreDir    = "/usr/local/camunda/truststore"
+	truststorePath   = truststoreDir + "/truststore.jks"
+	keystorePath     = truststoreDir + "/keystore.p12"
+	// storePassword protects the generated stores. They only live in an emptyDir of the pod,
+	// changeit is the password of the JDK cacerts the truststore is copied from.
+	storePassword = "changeit"
+)
+
+// usesJavaTruststore reports whether the database clients of the cluster rely on the JVM trust- and
+// keystore. The Elasticsearch and OpenSearch exporters have no certificate settings of their own.
+func usesJavaTruststore(database v1alpha1.Database) bool {
+	return database.TLS != nil && database.Type != v1alpha1.PostgresqlDatabaseType
+}
+
+// databaseTLSVolumes returns the volume with the referenced certificates and the volume the
+// truststore is generated into.
+func databaseTLSVolumes(database v1alpha1.Database) []corev1.Volume {
+	if database.TLS == nil {
+		return nil
+	}
+
+	var sources []corev1.VolumeProjection
+	if ca := database.TLS.CA; ca != nil {
+		if ca.SecretKeyRef != nil {
+			sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+				LocalObjectReference: ca.SecretKeyRef.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: ca.SecretKeyRef.Key, Path: "ca.crt"}},
+			}})
+		}
+		if ca.ConfigMapKeyRef != nil {
+			sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: ca.ConfigMapKeyRef.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: ca.ConfigMapKeyRef.Key, Path: "ca.crt"}},
+			}})
+		}
+	}
+	if cert := database.TLS.ClientCertificate; cert != nil {
+		sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+			LocalObjectReference: *cert,
+			Items: []corev1.KeyToPath{
+				{Key: corev1.TLSCertKey, Path: "tls.crt"},
+				{Key: corev1.TLSPrivateKeyKey, Path: "tls.key"},
+			},
+		}})
+	}
+
+	volumes := []corev1.Volume{{
+		Name: databaseTLSVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		},
+	}}
+	if usesJavaTruststore(database) {
+		volumes = append(volumes, corev1.Volume{
+			Name: truststoreVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	return volumes
+}
+
+func databaseTLSVolumeMounts(database v1alpha1.Database) []corev1.VolumeMount {
+	if database.TLS == nil {
+		return nil
+	}
+
+	mounts := []corev1.VolumeMount{{
+		Name:      databaseTLSVolume,
+		MountPath: databaseTLSPath,
+		ReadOnly:  true,
+	}}
+	if usesJavaTruststore(database) {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      truststoreVolume,
+			MountPath: truststoreDir,
+		})
+	}
+	return mounts
+}
+
+// truststoreInitContainers builds the JVM truststore from the JDK defaults and the database CA,
+// and converts the client certificate into a PKCS#12 keystore.
+func truststoreInitContainers(camunda v1alpha1.OrchestrationCluster) []corev1.Container {
+	database := camunda.Spec.Database
+	if !usesJavaTruststore(database) {
+		return nil
+	}
+
+	script := []string{
+		"set -e",
+		"cp \"$JAVA_HOME/lib/security/cacerts\" " + truststorePath,
+		"chmod u+w " + truststorePath,
+	}
+	if database.TLS.CA != nil {
+		script = append(script, "keytool -importcert -noprompt -alias database-ca -file "+databaseCAPath+
+			" -keystore "+truststorePath+" -storepass "+storePassword)
+	}
+	if database.TLS.ClientCertificate != nil {
+		script = append(script, "openssl pkcs12 -export -name database-client -in "+clientCertPath+
+			" -inkey "+clientKeyPath+" -out "+keystorePath+" -passout pass:"+storePassword)
+	}
+
+	return []corev1.Container{{
+		Name:            "truststore",
+		Image:           "camunda/camunda:" + camunda.Spec.Version,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"sh", "-c", strings.Join(script, "\n")},
+		SecurityContext: securityContext(),
+		VolumeMounts:    databaseTLSVolumeMounts(database),
+	}}
+}
+
+// javaTruststoreEnv points the JVM at the generated trust- and keystore.
+func javaTruststoreEnv(database v1alpha1.Database) []corev1.EnvVar {
+	if !usesJavaTruststore(database) {
+		return nil
+	}
+
+	opts := []string{
+		"-Djavax.net.ssl.trustStore=" + truststorePath,
+		"-Djavax.net.ssl.trustStorePassword=" + storePassword,
+	}
+	if database.TLS.ClientCertificate != nil {
+		opts = append(opts,
+			"-Djavax.net.ssl.keyStore="+keystorePath,
+			"-Djavax.net.ssl.keyStorePassword="+storePassword,
+			"-Djavax.net.ssl.keyStoreType=PKCS12",
+		)
+	}
+
+	return []corev1.EnvVar{{
+		Name:  "JAVA_TOOL_OPTIONS",
+		Value: strings.Join(opts, " "),
+	}}
+}
@@ -0,0 +1,32 @@
+package bundles
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ValidateUpgrade checks that the cluster can be upgraded from one version to another.
+// Camunda supports upgrades to the next minor version only, so downgrades, upgrades across
+// major versions and skipped minor versions are rejected. Patch upgrades are always allowed.
+func ValidateUpgrade(from, to string) error {
+	fromVersion, err := semver.NewVersion(from)
+	if err != nil {
+		return fmt.Errorf("invalid version format: %s", from)
+	}
+	toVersion, err := semver.NewVersion(to)
+	if err != nil {
+		return fmt.Errorf("invalid version format: %s", to)
+	}
+
+	switch {
+	case toVersion.LessThan(fromVersion):
+		return fmt.Errorf("downgrade from %s to %s is not supported", from, to)
+	case toVersion.Major() != fromVersion.Major():
+		return fmt.Errorf("upgrade from %s to %s across major versions is not supported", from, to)
+	case toVersion.Minor() > fromVersion.Minor()+1:
+		return fmt.Errorf("upgrade from %s to %s skips minor versions, upgrade to %d.%d first",
+			from, to, fromVersion.Major(), fromVersion.Minor()+1)
+	}
+	return nil
+}
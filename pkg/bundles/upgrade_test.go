@@ -0,0 +1,57 @@
+package bundles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUpgrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          string
+		to            string
+		errorContains string
+	}{
+		{name: "Same version", from: "8.7.7", to: "8.7.7"},
+		{name: "Patch upgrade", from: "8.7.0", to: "8.7.7"},
+		{name: "Next minor", from: "8.7.7", to: "8.8.0"},
+		{name: "Next minor pre-release", from: "8.7.7", to: "8.8.0-alpha5"},
+		{name: "Pre-release to release", from: "8.8.0-alpha5", to: "8.8.0"},
+		{
+			name:          "Downgrade",
+			from:          "8.7.7",
+			to:            "8.7.6",
+			errorContains: "downgrade from 8.7.7 to 8.7.6 is not supported",
+		},
+		{
+			name:          "Skipped minor",
+			from:          "8.6.3",
+			to:            "8.8.0",
+			errorContains: "upgrade to 8.7 first",
+		},
+		{
+			name:          "Major upgrade",
+			from:          "8.7.7",
+			to:            "9.0.0",
+			errorContains: "across major versions",
+		},
+		{
+			name:          "Invalid version",
+			from:          "8.7.7",
+			to:            "latest",
+			errorContains: "invalid version format: latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpgrade(tt.from, tt.to)
+			if tt.errorContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Package identity is a client for the users, groups and authorizations endpoints of the REST API a
+// Camunda cluster serves on its HTTP port. It syncs the users, groups and authorizations declared
+// as Kubernetes resources into the cluster.
+package identity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Keys of the Secret of the initial admin user of a cluster with basic authentication.
+const (
+	AdminUsernameKey = "username"
+	AdminPasswordKey = "password"
+)
+
+// ErrNotFound is returned when the requested user, group or authorization does not exist.
+var ErrNotFound = errors.New("not found")
+
+// User is a user of the cluster. The password is never returned by the API.
+type User struct {
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Group is a group of users.
+type Group struct {
+	GroupID     string `json:"groupId"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Authorization grants an owner permissions on a resource.
+type Authorization struct {
+	AuthorizationKey string   `json:"authorizationKey,omitempty"`
+	OwnerID          string   `json:"ownerId"`
+	OwnerType        string   `json:"ownerType"`
+	ResourceType     string   `json:"resourceType"`
+	ResourceID       string   `json:"resourceId"`
+	PermissionTypes  []string `json:"permissionTypes"`
+}
+
+// Client talks to the REST API of a single cluster.
+type Client struct {
+	httpClient *http.Client
+	baseURL    url.URL
+	username   string
+	password   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests, e.g. one that adds OAuth tokens.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBasicAuth authenticates the requests with the username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// NewClient returns a client for the REST API at the base URL, e.g. http://camunda:8080.
+func NewClient(baseURL url.URL, opts ...Option) *Client {
+	c := &Client{httpClient: &http.Client{}, baseURL: baseURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// User returns the user with the username.
+func (c *Client) User(ctx context.Context, username string) (*User, error) {
+	var user User
+	if err := c.get(ctx, c.url("users", username), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CreateUser creates the user with its password.
+func (c *Client) CreateUser(ctx context.Context, user User) error {
+	return c.do(ctx, http.MethodPost, c.url("users"), user, nil)
+}
+
+// UpdateUser updates the name and email of the user, and its password if it is set.
+func (c *Client) UpdateUser(ctx context.Context, user User) error {
+	body := struct {
+		Name     string `json:"name,omitempty"`
+		Email    string `json:"email,omitempty"`
+		Password string `json:"password,omitempty"`
+	}{Name: user.Name, Email: user.Email, Password: user.Password}
+	return c.do(ctx, http.MethodPut, c.url("users", user.Username), body, nil)
+}
+
+// DeleteUser deletes the user. Deleting a user that does not exist succeeds.
+func (c *Client) DeleteUser(ctx context.Context, username string) error {
+	return c.delete(ctx, c.url("users", username))
+}
+
+// Group returns the group with the id.
+func (c *Client) Group(ctx context.Context, groupID string) (*Group, error) {
+	var group Group
+	if err := c.get(ctx, c.url("groups", groupID), &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// CreateGroup creates the group.
+func (c *Client) CreateGroup(ctx context.Context, group Group) error {
+	return c.do(ctx, http.MethodPost, c.url("groups"), group, nil)
+}
+
+// UpdateGroup updates the name and description of the group.
+func (c *Client) UpdateGroup(ctx context.Context, group Group) error {
+	body := struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}{Name: group.Name, Description: group.Description}
+	return c.do(ctx, http.MethodPut, c.url("groups", group.GroupID), body, nil)
+}
+
+// DeleteGroup deletes the group. Deleting a group that does not exist succeeds.
+func (c *Client) DeleteGroup(ctx context.Context, groupID string) error {
+	return c.delete(ctx, c.url("groups", groupID))
+}
+
+// maxGroupMembers is the page size the members of a group are read with.
+const maxGroupMembers = 1000
+
+// GroupMembers returns the usernames of the members of the group.
+func (c *Client) GroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	query := map[string]any{"page": map[string]int{"limit": maxGroupMembers}}
+	var result struct {
+		Items []struct {
+			Username string `json:"username"`
+		} `json:"items"`
+	}
+	if err := c.do(ctx, http.MethodPost, c.url("groups", groupID, "users", "search"), query, &result); err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		members = append(members, item.Username)
+	}
+	return members, nil
+}
+
+// AddGroupMember adds the user to the group.
+func (c *Client) AddGroupMember(ctx context.Context, groupID, username string) error {
+	return c.do(ctx, http.MethodPut, c.url("groups", groupID, "users", username), nil, nil)
+}
+
+// RemoveGroupMember removes the user from the group.
+func (c *Client) RemoveGroupMember(ctx context.Context, groupID, username string) error {
+	return c.delete(ctx, c.url("groups", groupID, "users", username))
+}
+
+// Authorization returns the authorization with the key.
+func (c *Client) Authorization(ctx context.Context, key string) (*Authorization, error) {
+	var authorization Authorization
+	if err := c.get(ctx, c.url("authorizations", key), &authorization); err != nil {
+		return nil, err
+	}
+	return &authorization, nil
+}
+
+// CreateAuthorization creates the authorization and returns its key.
+func (c *Client) CreateAuthorization(ctx context.Context, authorization Authorization) (string, error) {
+	authorization.AuthorizationKey = ""
+	var result struct {
+		AuthorizationKey string `json:"authorizationKey"`
+	}
+	if err := c.do(ctx, http.MethodPost, c.url("authorizations"), authorization, &result); err != nil {
+		return "", err
+	}
+	return result.AuthorizationKey, nil
+}
+
+// UpdateAuthorization replaces the authorization with the key.
+func (c *Client) UpdateAuthorization(ctx context.Context, key string, authorization Authorization) error {
+	authorization.AuthorizationKey = ""
+	return c.do(ctx, http.MethodPut, c.url("authorizations", key), authorization, nil)
+}
+
+// DeleteAuthorization deletes the authorization. Deleting an authorization that does not exist succeeds.
+func (c *Client) DeleteAuthorization(ctx context.Context, key string) error {
+	return c.delete(ctx, c.url("authorizations", key))
+}
+
+// get reads a resource and returns ErrNotFound if it does not exist.
+func (c *Client) get(ctx context.Context, u url.URL, out any) error {
+	err := c.do(ctx, http.MethodGet, u, nil, out)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// delete deletes a resource. Deleting a resource that does not exist succeeds.
+func (c *Client) delete(ctx context.Context, u url.URL) error {
+	err := c.do(ctx, http.MethodDelete, u, nil, nil)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) url(elem ...string) url.URL {
+	u := c.baseURL
+	u.Path, _ = url.JoinPath(u.Path, append([]string{"v2"}, elem...)...)
+	return u
+}
+
+func (c *Client) do(ctx context.Context, method string, u url.URL, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return &statusError{method: method, path: u.Path, code: res.StatusCode, message: string(message)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// statusError is returned for responses with an unsuccessful status code.
+type statusError struct {
+	method  string
+	path    string
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s: received status code %d: %s", e.method, e.path, e.code, e.message)
+}
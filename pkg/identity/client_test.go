@@ -0,0 +1,112 @@
+package identity_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/camunda/camunda-operator/pkg/identity"
+	"github.com/camunda/camunda-operator/pkg/identity/identitytest"
+)
+
+func TestClient_User(t *testing.T) {
+	server := identitytest.NewServer()
+	defer server.Close()
+	client := identity.NewClient(server.BaseURL())
+
+	_, err := client.User(t.Context(), "demo")
+	assert.ErrorIs(t, err, identity.ErrNotFound)
+
+	require.NoError(t, client.CreateUser(t.Context(), identity.User{Username: "demo", Name: "Demo", Password: "secret"}))
+	user, err := client.User(t.Context(), "demo")
+	require.NoError(t, err)
+	assert.Equal(t, identity.User{Username: "demo", Name: "Demo"}, *user, "The password is not returned")
+
+	require.NoError(t, client.UpdateUser(t.Context(), identity.User{Username: "demo", Email: "demo@example.com"}))
+	stored, _ := server.User("demo")
+	assert.Equal(t, identity.User{Username: "demo", Email: "demo@example.com", Password: "secret"}, stored)
+
+	require.NoError(t, client.DeleteUser(t.Context(), "demo"))
+	assert.NoError(t, client.DeleteUser(t.Context(), "demo"), "Deleting a missing user succeeds")
+}
+
+func TestClient_GroupMembers(t *testing.T) {
+	server := identitytest.NewServer()
+	defer server.Close()
+	client := identity.NewClient(server.BaseURL())
+
+	require.NoError(t, client.CreateGroup(t.Context(), identity.Group{GroupID: "ops", Name: "Operations"}))
+	require.NoError(t, client.AddGroupMember(t.Context(), "ops", "alice"))
+	require.NoError(t, client.AddGroupMember(t.Context(), "ops", "bob"))
+	require.NoError(t, client.RemoveGroupMember(t.Context(), "ops", "alice"))
+
+	members, err := client.GroupMembers(t.Context(), "ops")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, members)
+
+	_, err = client.GroupMembers(t.Context(), "missing")
+	assert.Error(t, err)
+}
+
+func TestClient_Authorization(t *testing.T) {
+	server := identitytest.NewServer()
+	defer server.Close()
+	client := identity.NewClient(server.BaseURL())
+
+	authorization := identity.Authorization{
+		OwnerID:         "ops",
+		OwnerType:       "GROUP",
+		ResourceType:    "PROCESS_DEFINITION",
+		ResourceID:      "*",
+		PermissionTypes: []string{"READ_PROCESS_DEFINITION"},
+	}
+	key, err := client.CreateAuthorization(t.Context(), authorization)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	current, err := client.Authorization(t.Context(), key)
+	require.NoError(t, err)
+	assert.Equal(t, key, current.AuthorizationKey)
+
+	require.NoError(t, client.DeleteAuthorization(t.Context(), key))
+	_, err = client.Authorization(t.Context(), key)
+	assert.ErrorIs(t, err, identity.ErrNotFound)
+}
+
+func TestClient_BasicAuth(t *testing.T) {
+	var username, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := identity.NewClient(*baseURL, identity.WithBasicAuth("admin", "secret"))
+
+	require.NoError(t, client.DeleteUser(t.Context(), "demo"))
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestDiscoverTokenURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/camunda/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token_endpoint":"https://idp.example.com/token"}`))
+	}))
+	defer server.Close()
+
+	tokenURL, err := identity.DiscoverTokenURL(t.Context(), server.Client(), server.URL+"/realms/camunda/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/token", tokenURL)
+
+	_, err = identity.DiscoverTokenURL(t.Context(), server.Client(), server.URL)
+	assert.Error(t, err)
+}
@@ -0,0 +1,351 @@
+// Package identitytest provides a fake of the users, groups and authorizations endpoints of the
+// REST API for tests.
+package identitytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/camunda/camunda-operator/pkg/identity"
+)
+
+// Server is a fake REST API. Unlike the real API it returns the passwords of users, so tests can
+// check which password was set.
+type Server struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	users          map[string]identity.User
+	groups         map[string]identity.Group
+	members        map[string][]string
+	authorizations map[string]identity.Authorization
+	nextKey        int64
+	writes         int
+}
+
+// NewServer starts a fake REST API. It is closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		users:          map[string]identity.User{},
+		groups:         map[string]identity.Group{},
+		members:        map[string][]string{},
+		authorizations: map[string]identity.Authorization{},
+		nextKey:        2251799813685249,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v2/users", s.createUser)
+	mux.HandleFunc("GET /v2/users/{username}", s.getUser)
+	mux.HandleFunc("PUT /v2/users/{username}", s.updateUser)
+	mux.HandleFunc("DELETE /v2/users/{username}", s.deleteUser)
+	mux.HandleFunc("POST /v2/groups", s.createGroup)
+	mux.HandleFunc("GET /v2/groups/{id}", s.getGroup)
+	mux.HandleFunc("PUT /v2/groups/{id}", s.updateGroup)
+	mux.HandleFunc("DELETE /v2/groups/{id}", s.deleteGroup)
+	mux.HandleFunc("POST /v2/groups/{id}/users/search", s.searchMembers)
+	mux.HandleFunc("PUT /v2/groups/{id}/users/{username}", s.addMember)
+	mux.HandleFunc("DELETE /v2/groups/{id}/users/{username}", s.removeMember)
+	mux.HandleFunc("POST /v2/authorizations", s.createAuthorization)
+	mux.HandleFunc("GET /v2/authorizations/{key}", s.getAuthorization)
+	mux.HandleFunc("PUT /v2/authorizations/{key}", s.updateAuthorization)
+	mux.HandleFunc("DELETE /v2/authorizations/{key}", s.deleteAuthorization)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// BaseURL returns the URL the client is created with.
+func (s *Server) BaseURL() url.URL {
+	u, _ := url.Parse(s.URL)
+	return *u
+}
+
+// User returns the user including its password.
+func (s *Server) User(username string) (identity.User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[username]
+	return user, ok
+}
+
+// Group returns the group.
+func (s *Server) Group(groupID string) (identity.Group, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, ok := s.groups[groupID]
+	return group, ok
+}
+
+// Members returns the usernames of the members of the group in the order they were added.
+func (s *Server) Members(groupID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.members[groupID])
+}
+
+// Authorization returns the authorization with the key.
+func (s *Server) Authorization(key string) (identity.Authorization, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	authorization, ok := s.authorizations[key]
+	return authorization, ok
+}
+
+// Writes returns the number of requests that created, changed or deleted anything.
+func (s *Server) Writes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writes
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var user identity.User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil || user.Username == "" || user.Password == "" {
+		http.Error(w, `{"detail":"username and password are required"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.users[user.Username]; exists {
+		http.Error(w, `{"detail":"user already exists"}`, http.StatusConflict)
+		return
+	}
+	s.users[user.Username] = user
+	s.writes++
+	writeJSON(w, http.StatusCreated, withoutPassword(user))
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[r.PathValue("username")]
+	if !ok {
+		http.Error(w, `{"detail":"user not found"}`, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, withoutPassword(user))
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var update identity.User
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, `{"detail":"invalid user"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[r.PathValue("username")]
+	if !ok {
+		http.Error(w, `{"detail":"user not found"}`, http.StatusNotFound)
+		return
+	}
+	user.Name = update.Name
+	user.Email = update.Email
+	if update.Password != "" {
+		user.Password = update.Password
+	}
+	s.users[user.Username] = user
+	s.writes++
+	writeJSON(w, http.StatusOK, withoutPassword(user))
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	username := r.PathValue("username")
+	if _, ok := s.users[username]; !ok {
+		http.Error(w, `{"detail":"user not found"}`, http.StatusNotFound)
+		return
+	}
+	delete(s.users, username)
+	for groupID, members := range s.members {
+		s.members[groupID] = slices.DeleteFunc(members, func(member string) bool { return member == username })
+	}
+	s.writes++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	var group identity.Group
+	if err := json.NewDecoder(r.Body).Decode(&group); err != nil || group.GroupID == "" || group.Name == "" {
+		http.Error(w, `{"detail":"groupId and name are required"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.groups[group.GroupID]; exists {
+		http.Error(w, `{"detail":"group already exists"}`, http.StatusConflict)
+		return
+	}
+	s.groups[group.GroupID] = group
+	s.writes++
+	writeJSON(w, http.StatusCreated, group)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, ok := s.groups[r.PathValue("id")]
+	if !ok {
+		http.Error(w, `{"detail":"group not found"}`, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, group)
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	var update identity.Group
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil || update.Name == "" {
+		http.Error(w, `{"detail":"name is required"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, ok := s.groups[r.PathValue("id")]
+	if !ok {
+		http.Error(w, `{"detail":"group not found"}`, http.StatusNotFound)
+		return
+	}
+	group.Name = update.Name
+	group.Description = update.Description
+	s.groups[group.GroupID] = group
+	s.writes++
+	writeJSON(w, http.StatusOK, group)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groupID := r.PathValue("id")
+	if _, ok := s.groups[groupID]; !ok {
+		http.Error(w, `{"detail":"group not found"}`, http.StatusNotFound)
+		return
+	}
+	delete(s.groups, groupID)
+	delete(s.members, groupID)
+	s.writes++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) searchMembers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groupID := r.PathValue("id")
+	if _, ok := s.groups[groupID]; !ok {
+		http.Error(w, `{"detail":"group not found"}`, http.StatusNotFound)
+		return
+	}
+	items := []map[string]string{}
+	for _, member := range s.members[groupID] {
+		items = append(items, map[string]string{"username": member})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (s *Server) addMember(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groupID, username := r.PathValue("id"), r.PathValue("username")
+	if _, ok := s.groups[groupID]; !ok {
+		http.Error(w, `{"detail":"group not found"}`, http.StatusNotFound)
+		return
+	}
+	if slices.Contains(s.members[groupID], username) {
+		http.Error(w, `{"detail":"user is already a member"}`, http.StatusConflict)
+		return
+	}
+	s.members[groupID] = append(s.members[groupID], username)
+	s.writes++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groupID, username := r.PathValue("id"), r.PathValue("username")
+	if !slices.Contains(s.members[groupID], username) {
+		http.Error(w, `{"detail":"user is not a member"}`, http.StatusNotFound)
+		return
+	}
+	s.members[groupID] = slices.DeleteFunc(s.members[groupID], func(member string) bool { return member == username })
+	s.writes++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createAuthorization(w http.ResponseWriter, r *http.Request) {
+	var authorization identity.Authorization
+	if err := json.NewDecoder(r.Body).Decode(&authorization); err != nil || authorization.OwnerID == "" {
+		http.Error(w, `{"detail":"ownerId is required"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	authorization.AuthorizationKey = strconv.FormatInt(s.nextKey, 10)
+	s.nextKey++
+	s.authorizations[authorization.AuthorizationKey] = authorization
+	s.writes++
+	writeJSON(w, http.StatusCreated, map[string]string{"authorizationKey": authorization.AuthorizationKey})
+}
+
+func (s *Server) getAuthorization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	authorization, ok := s.authorizations[r.PathValue("key")]
+	if !ok {
+		http.Error(w, `{"detail":"authorization not found"}`, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, authorization)
+}
+
+func (s *Server) updateAuthorization(w http.ResponseWriter, r *http.Request) {
+	var update identity.Authorization
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, `{"detail":"invalid authorization"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.PathValue("key")
+	if _, ok := s.authorizations[key]; !ok {
+		http.Error(w, `{"detail":"authorization not found"}`, http.StatusNotFound)
+		return
+	}
+	update.AuthorizationKey = key
+	s.authorizations[key] = update
+	s.writes++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteAuthorization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.PathValue("key")
+	if _, ok := s.authorizations[key]; !ok {
+		http.Error(w, `{"detail":"authorization not found"}`, http.StatusNotFound)
+		return
+	}
+	delete(s.authorizations, key)
+	s.writes++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func withoutPassword(user identity.User) identity.User {
+	user.Password = ""
+	return user
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
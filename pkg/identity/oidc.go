@@ -0,0 +1,39 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DiscoverTokenURL returns the token endpoint from the OpenID Connect discovery document of the issuer.
+func DiscoverTokenURL(ctx context.Context, httpClient *http.Client, issuerURL string) (string, error) {
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: received status code %d", discoveryURL, res.StatusCode)
+	}
+
+	var configuration struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&configuration); err != nil {
+		return "", fmt.Errorf("invalid discovery document of %s: %w", issuerURL, err)
+	}
+	if configuration.TokenEndpoint == "" {
+		return "", fmt.Errorf("discovery document of %s has no token endpoint", issuerURL)
+	}
+	return configuration.TokenEndpoint, nil
+}
@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// SyncUser creates the user or updates its name and email if they drifted. The API does not return
+// passwords, so the password of an existing user is only set if setPassword is true, e.g. after the
+// Secret holding it changed.
+func SyncUser(ctx context.Context, c *Client, desired User, setPassword bool) error {
+	current, err := c.User(ctx, desired.Username)
+	if errors.Is(err, ErrNotFound) {
+		return c.CreateUser(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !setPassword {
+		if current.Name == desired.Name && current.Email == desired.Email {
+			return nil
+		}
+		desired.Password = ""
+	}
+	return c.UpdateUser(ctx, desired)
+}
+
+// SyncGroup creates the group or updates its name and description if they drifted, and makes the
+// users the only members of the group.
+func SyncGroup(ctx context.Context, c *Client, desired Group, users []string) error {
+	current, err := c.Group(ctx, desired.GroupID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if err := c.CreateGroup(ctx, desired); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case current.Name != desired.Name || current.Description != desired.Description:
+		if err := c.UpdateGroup(ctx, desired); err != nil {
+			return err
+		}
+	}
+
+	members, err := c.GroupMembers(ctx, desired.GroupID)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if slices.Contains(members, user) {
+			continue
+		}
+		if err := c.AddGroupMember(ctx, desired.GroupID, user); err != nil {
+			return fmt.Errorf("failed to add user %s to group %s: %w", user, desired.GroupID, err)
+		}
+	}
+	for _, member := range members {
+		if slices.Contains(users, member) {
+			continue
+		}
+		if err := c.RemoveGroupMember(ctx, desired.GroupID, member); err != nil {
+			return fmt.Errorf("failed to remove user %s from group %s: %w", member, desired.GroupID, err)
+		}
+	}
+	return nil
+}
+
+// SyncAuthorization creates the authorization if key is empty or the authorization with the key no
+// longer exists, and replaces it if it drifted. It returns the key of the authorization.
+func SyncAuthorization(ctx context.Context, c *Client, key string, desired Authorization) (string, error) {
+	if key == "" {
+		return c.CreateAuthorization(ctx, desired)
+	}
+	current, err := c.Authorization(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return c.CreateAuthorization(ctx, desired)
+	}
+	if err != nil {
+		return "", err
+	}
+	if equalAuthorizations(*current, desired) {
+		return key, nil
+	}
+	return key, c.UpdateAuthorization(ctx, key, desired)
+}
+
+// equalAuthorizations compares the authorizations ignoring their keys and the order of the permissions.
+func equalAuthorizations(a, b Authorization) bool {
+	return a.OwnerID == b.OwnerID &&
+		a.OwnerType == b.OwnerType &&
+		a.ResourceType == b.ResourceType &&
+		a.ResourceID == b.ResourceID &&
+		slices.Equal(slices.Sorted(slices.Values(a.PermissionTypes)), slices.Sorted(slices.Values(b.PermissionTypes)))
+}
@@ -0,0 +1,95 @@
+package identity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/camunda/camunda-operator/pkg/identity"
+	"github.com/camunda/camunda-operator/pkg/identity/identitytest"
+)
+
+func TestSyncUser(t *testing.T) {
+	server := identitytest.NewServer()
+	defer server.Close()
+	client := identity.NewClient(server.BaseURL())
+	desired := identity.User{Username: "demo", Name: "Demo", Password: "secret"}
+
+	require.NoError(t, identity.SyncUser(t.Context(), client, desired, false))
+	user, _ := server.User("demo")
+	assert.Equal(t, desired, user, "The user is created with its password")
+
+	writes := server.Writes()
+	require.NoError(t, identity.SyncUser(t.Context(), client, desired, false))
+	assert.Equal(t, writes, server.Writes(), "A user in sync is not updated")
+
+	desired.Email = "demo@example.com"
+	desired.Password = "changed"
+	require.NoError(t, identity.SyncUser(t.Context(), client, desired, false))
+	user, _ = server.User("demo")
+	assert.Equal(t, "demo@example.com", user.Email)
+	assert.Equal(t, "secret", user.Password, "The password is only set if requested")
+
+	require.NoError(t, identity.SyncUser(t.Context(), client, desired, true))
+	user, _ = server.User("demo")
+	assert.Equal(t, "changed", user.Password)
+}
+
+func TestSyncGroup(t *testing.T) {
+	server := identitytest.NewServer()
+	defer server.Close()
+	client := identity.NewClient(server.BaseURL())
+	desired := identity.Group{GroupID: "ops", Name: "Operations"}
+
+	require.NoError(t, identity.SyncGroup(t.Context(), client, desired, []string{"alice", "bob"}))
+	assert.Equal(t, []string{"alice", "bob"}, server.Members("ops"))
+
+	require.NoError(t, client.AddGroupMember(t.Context(), "ops", "mallory"))
+	desired.Description = "On call engineers"
+	require.NoError(t, identity.SyncGroup(t.Context(), client, desired, []string{"bob", "carol"}))
+
+	group, _ := server.Group("ops")
+	assert.Equal(t, desired, group)
+	assert.Equal(t, []string{"bob", "carol"}, server.Members("ops"), "Members not declared are removed")
+
+	writes := server.Writes()
+	require.NoError(t, identity.SyncGroup(t.Context(), client, desired, []string{"carol", "bob"}))
+	assert.Equal(t, writes, server.Writes(), "A group in sync is not updated")
+}
+
+func TestSyncAuthorization(t *testing.T) {
+	server := identitytest.NewServer()
+	defer server.Close()
+	client := identity.NewClient(server.BaseURL())
+	desired := identity.Authorization{
+		OwnerID:         "ops",
+		OwnerType:       "GROUP",
+		ResourceType:    "PROCESS_DEFINITION",
+		ResourceID:      "*",
+		PermissionTypes: []string{"READ_PROCESS_DEFINITION", "READ_PROCESS_INSTANCE"},
+	}
+
+	key, err := identity.SyncAuthorization(t.Context(), client, "", desired)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	writes := server.Writes()
+	desired.PermissionTypes = []string{"READ_PROCESS_INSTANCE", "READ_PROCESS_DEFINITION"}
+	synced, err := identity.SyncAuthorization(t.Context(), client, key, desired)
+	require.NoError(t, err)
+	assert.Equal(t, key, synced)
+	assert.Equal(t, writes, server.Writes(), "The order of the permissions does not matter")
+
+	desired.ResourceID = "order-process"
+	synced, err = identity.SyncAuthorization(t.Context(), client, key, desired)
+	require.NoError(t, err)
+	assert.Equal(t, key, synced, "A drifted authorization is updated in place")
+	current, _ := server.Authorization(key)
+	assert.Equal(t, "order-process", current.ResourceID)
+
+	require.NoError(t, client.DeleteAuthorization(t.Context(), key))
+	synced, err = identity.SyncAuthorization(t.Context(), client, key, desired)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, synced, "A deleted authorization is recreated")
+}
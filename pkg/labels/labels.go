@@ -14,6 +14,29 @@ func CreateSelector(osc *corev1alpha1.OrchestrationCluster) map[string]string {
 	return commonLabels(osc)
 }
 
+// CreateGateway creates the labels of the standalone gateway pods. They differ from the broker
+// labels in the component, so the selectors of the brokers do not match the gateways.
+func CreateGateway(osc *corev1alpha1.OrchestrationCluster) map[string]string {
+	l := Create(osc)
+	l["app.kubernetes.io/component"] = "gateway"
+	return l
+}
+
+// CreateGatewaySelector creates the selector labels for the standalone gateway pods.
+func CreateGatewaySelector(osc *corev1alpha1.OrchestrationCluster) map[string]string {
+	l := commonLabels(osc)
+	l["app.kubernetes.io/component"] = "gateway"
+	return l
+}
+
+// CreateClusterSelector creates the selector labels for all pods of the cluster, the brokers and
+// the standalone gateways.
+func CreateClusterSelector(osc *corev1alpha1.OrchestrationCluster) map[string]string {
+	l := commonLabels(osc)
+	delete(l, "app.kubernetes.io/component")
+	return l
+}
+
 // commonLabels returns common labels for the OrchestrationCluster.
 // The version is not included as it changes frequently and should not be used for a label selector for example.
 func commonLabels(osc *corev1alpha1.OrchestrationCluster) map[string]string {
@@ -0,0 +1,234 @@
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// PollInterval is the interval in which the progress of a scaling operation is checked.
+const PollInterval = 10 * time.Second
+
+// Cluster is the part of the Zeebe cluster management API needed to scale brokers.
+// It is implemented by management.Cluster.
+type Cluster interface {
+	Topology(ctx context.Context) (*management.TopologyResponse, error)
+	ScaleBrokers(
+		ctx context.Context,
+		brokerIds []management.BrokerId,
+		dryRun bool,
+		force bool,
+		replicationFactor *int32,
+	) (*management.PlannedOperationsResponse, error)
+}
+
+// Result is the outcome of a single step of the scaling state machine.
+type Result struct {
+	// Replicas is the number of replicas the StatefulSet must have.
+	Replicas int32
+	// Scaling is the scaling status to persist. It is nil if no scaling operation is in progress.
+	Scaling *v1alpha1.ScalingStatus
+	// RequeueAfter is set while the operation is waiting for the cluster to converge.
+	RequeueAfter time.Duration
+}
+
+// Step advances the broker scaling state machine of the cluster by one step.
+//
+// Scaling up first adds the new brokers to the StatefulSet, waits until they are ready, and then
+// requests the brokers to join the topology. Scaling down first requests the brokers to leave the
+// topology and only removes them from the StatefulSet once their partitions have been moved.
+// The state is kept in osc.Status.Scaling, so an interrupted operation continues where it stopped.
+// The StatefulSet is nil if it has not been created yet.
+func Step(
+	ctx context.Context,
+	osc *v1alpha1.OrchestrationCluster,
+	sts *appsv1.StatefulSet,
+	cluster Cluster,
+) (Result, error) {
+	desired := osc.Spec.ClusterSize
+	scaling := osc.Status.Scaling.DeepCopy()
+
+	if scaling == nil {
+		if !InProgress(osc, sts) {
+			return Result{Replicas: desired}, nil
+		}
+		if osc.Status.Upgrade != nil {
+			// Scaling starts once the version upgrade completed.
+			return Result{Replicas: *sts.Spec.Replicas, RequeueAfter: PollInterval}, nil
+		}
+		scaling = start(*sts.Spec.Replicas, desired)
+	}
+
+	switch scaling.Phase {
+	case v1alpha1.ScalingPhaseScalingStatefulSet:
+		// The new brokers have not been requested to join yet, so the target can still be changed.
+		if desired != scaling.ToClusterSize {
+			if desired <= scaling.FromClusterSize {
+				return Step(ctx, withoutScaling(osc), stsWithReplicas(sts, scaling.FromClusterSize), cluster)
+			}
+			scaling.ToClusterSize = desired
+		}
+		if sts == nil || sts.Status.ReadyReplicas < scaling.ToClusterSize {
+			scaling.Message = fmt.Sprintf("waiting for %d brokers to be ready", scaling.ToClusterSize)
+			return waiting(scaling.ToClusterSize, scaling), nil
+		}
+		scaling.Phase = v1alpha1.ScalingPhaseRequestingChange
+		scaling.Message = ""
+		fallthrough
+
+	case v1alpha1.ScalingPhaseRequestingChange:
+		if err := requestChange(ctx, scaling, cluster); err != nil {
+			return Result{}, err
+		}
+		return waiting(maxReplicas(scaling), scaling), nil
+
+	case v1alpha1.ScalingPhaseAwaitingChange:
+		done, err := awaitChange(ctx, scaling, cluster)
+		if err != nil {
+			return Result{}, err
+		}
+		if !done {
+			return waiting(maxReplicas(scaling), scaling), nil
+		}
+		if scaling.ToClusterSize > scaling.FromClusterSize {
+			return Result{Replicas: scaling.ToClusterSize}, nil
+		}
+		scaling.Phase = v1alpha1.ScalingPhaseShrinkingStatefulSet
+		scaling.Message = ""
+		fallthrough
+
+	case v1alpha1.ScalingPhaseShrinkingStatefulSet:
+		if sts != nil && sts.Status.Replicas > scaling.ToClusterSize {
+			scaling.Message = fmt.Sprintf("waiting for %d brokers to be removed",
+				sts.Status.Replicas-scaling.ToClusterSize)
+			return waiting(scaling.ToClusterSize, scaling), nil
+		}
+		return Result{Replicas: scaling.ToClusterSize}, nil
+	}
+
+	return Result{}, fmt.Errorf("unknown scaling phase %q", scaling.Phase)
+}
+
+// InProgress returns true if a scaling operation is in progress or has to be started because
+// the cluster size differs from the replicas of the StatefulSet.
+func InProgress(osc *v1alpha1.OrchestrationCluster, sts *appsv1.StatefulSet) bool {
+	if osc.Status.Scaling != nil {
+		return true
+	}
+	return sts != nil && sts.Spec.Replicas != nil && *sts.Spec.Replicas != osc.Spec.ClusterSize
+}
+
+func start(from, to int32) *v1alpha1.ScalingStatus {
+	phase := v1alpha1.ScalingPhaseScalingStatefulSet
+	if to < from {
+		phase = v1alpha1.ScalingPhaseRequestingChange
+	}
+	return &v1alpha1.ScalingStatus{
+		Phase:           phase,
+		FromClusterSize: from,
+		ToClusterSize:   to,
+		StartedAt:       metav1.Now(),
+	}
+}
+
+// requestChange asks the cluster to scale to the target brokers. If the cluster is already
+// running a change, e.g. because the operator restarted before it could persist the change id,
+// that change is adopted instead of requesting a new one.
+func requestChange(ctx context.Context, scaling *v1alpha1.ScalingStatus, cluster Cluster) error {
+	topo, err := cluster.Topology(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch topology: %w", err)
+	}
+
+	switch {
+	case len(topo.PendingChange.Pending) > 0:
+		scaling.ChangeID = int64(topo.PendingChange.ID)
+	case hasExactlyBrokers(topo, scaling.ToClusterSize):
+		// Nothing to change, only the StatefulSet needs to follow.
+		scaling.ChangeID = int64(topo.LastChange.ID)
+	default:
+		brokers := make([]management.BrokerId, 0, scaling.ToClusterSize)
+		for id := int32(0); id < scaling.ToClusterSize; id++ {
+			brokers = append(brokers, management.BrokerId(id))
+		}
+		planned, err := cluster.ScaleBrokers(ctx, brokers, false, false, nil)
+		if err != nil {
+			return fmt.Errorf("failed to request scaling to %d brokers: %w", scaling.ToClusterSize, err)
+		}
+		scaling.ChangeID = int64(planned.ChangeId)
+	}
+
+	scaling.Phase = v1alpha1.ScalingPhaseAwaitingChange
+	scaling.Message = fmt.Sprintf("waiting for topology change %d", scaling.ChangeID)
+	return nil
+}
+
+// awaitChange returns true once the topology consists of the target brokers. If the requested
+// change finished without reaching the target, e.g. because it failed, it is requested again.
+func awaitChange(ctx context.Context, scaling *v1alpha1.ScalingStatus, cluster Cluster) (bool, error) {
+	topo, err := cluster.Topology(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch topology: %w", err)
+	}
+
+	pending := len(topo.PendingChange.Pending)
+	switch {
+	case pending > 0 && topo.PendingChange.ID == management.ChangeId(scaling.ChangeID):
+		scaling.Message = fmt.Sprintf("topology change %d has %d pending operations", scaling.ChangeID, pending)
+		return false, nil
+	case hasExactlyBrokers(topo, scaling.ToClusterSize):
+		return true, nil
+	case pending > 0:
+		scaling.Message = fmt.Sprintf("waiting for unrelated topology change %d to finish", topo.PendingChange.ID)
+		return false, nil
+	}
+
+	scaling.Phase = v1alpha1.ScalingPhaseRequestingChange
+	scaling.Message = fmt.Sprintf("topology change %d finished with status %s without reaching %d brokers, retrying",
+		scaling.ChangeID, topo.LastChange.Status, scaling.ToClusterSize)
+	return false, nil
+}
+
+// hasExactlyBrokers returns true if the topology consists of the active brokers 0..size-1.
+func hasExactlyBrokers(topo *management.TopologyResponse, size int32) bool {
+	active := 0
+	for _, broker := range topo.Brokers {
+		if broker.State == management.BrokerStateLeft {
+			continue
+		}
+		if broker.State != management.BrokerStateActive || int32(broker.ID) >= size {
+			return false
+		}
+		active++
+	}
+	return int32(active) == size
+}
+
+func waiting(replicas int32, scaling *v1alpha1.ScalingStatus) Result {
+	return Result{Replicas: replicas, Scaling: scaling, RequeueAfter: PollInterval}
+}
+
+func maxReplicas(scaling *v1alpha1.ScalingStatus) int32 {
+	return max(scaling.FromClusterSize, scaling.ToClusterSize)
+}
+
+func withoutScaling(osc *v1alpha1.OrchestrationCluster) *v1alpha1.OrchestrationCluster {
+	osc = osc.DeepCopy()
+	osc.Status.Scaling = nil
+	return osc
+}
+
+func stsWithReplicas(sts *appsv1.StatefulSet, replicas int32) *appsv1.StatefulSet {
+	if sts == nil {
+		return nil
+	}
+	sts = sts.DeepCopy()
+	sts.Spec.Replicas = &replicas
+	return sts
+}
@@ -0,0 +1,222 @@
+package scaling
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+type fakeCluster struct {
+	topology   *management.TopologyResponse
+	scaleCalls [][]management.BrokerId
+	scaleErr   error
+}
+
+func (f *fakeCluster) Topology(_ context.Context) (*management.TopologyResponse, error) {
+	return f.topology, nil
+}
+
+func (f *fakeCluster) ScaleBrokers(
+	_ context.Context,
+	brokerIds []management.BrokerId,
+	_ bool,
+	_ bool,
+	_ *int32,
+) (*management.PlannedOperationsResponse, error) {
+	f.scaleCalls = append(f.scaleCalls, brokerIds)
+	if f.scaleErr != nil {
+		return nil, f.scaleErr
+	}
+	return &management.PlannedOperationsResponse{ChangeId: 42}, nil
+}
+
+func topology(brokers int32) *management.TopologyResponse {
+	topo := &management.TopologyResponse{Version: 1}
+	for id := int32(0); id < brokers; id++ {
+		topo.Brokers = append(topo.Brokers, management.BrokerState{
+			ID:    management.BrokerId(id),
+			State: management.BrokerStateActive,
+		})
+	}
+	return topo
+}
+
+func cluster(size int32, scaling *v1alpha1.ScalingStatus) *v1alpha1.OrchestrationCluster {
+	return &v1alpha1.OrchestrationCluster{
+		Spec:   v1alpha1.OrchestrationClusterSpec{ClusterSize: size},
+		Status: v1alpha1.OrchestrationClusterStatus{Scaling: scaling},
+	}
+}
+
+func statefulSet(replicas, ready int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		Spec:   appsv1.StatefulSetSpec{Replicas: ptr.To(replicas)},
+		Status: appsv1.StatefulSetStatus{Replicas: replicas, ReadyReplicas: ready},
+	}
+}
+
+func TestStep_NoScaling(t *testing.T) {
+	t.Run("new cluster", func(t *testing.T) {
+		result, err := Step(context.Background(), cluster(3, nil), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Replicas: 3}, result)
+	})
+
+	t.Run("cluster size unchanged", func(t *testing.T) {
+		result, err := Step(context.Background(), cluster(3, nil), statefulSet(3, 3), nil)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Replicas: 3}, result)
+	})
+}
+
+func TestStep_ScaleUp(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCluster{topology: topology(3)}
+
+	// The StatefulSet is scaled first.
+	osc := cluster(5, nil)
+	result, err := Step(ctx, osc, statefulSet(3, 3), fake)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), result.Replicas)
+	require.NotNil(t, result.Scaling)
+	assert.Equal(t, v1alpha1.ScalingPhaseScalingStatefulSet, result.Scaling.Phase)
+	assert.Equal(t, int32(3), result.Scaling.FromClusterSize)
+	assert.Equal(t, int32(5), result.Scaling.ToClusterSize)
+	assert.Equal(t, PollInterval, result.RequeueAfter)
+	assert.Empty(t, fake.scaleCalls)
+
+	// Brokers are added once all pods are ready.
+	osc.Status.Scaling = result.Scaling
+	result, err = Step(ctx, osc, statefulSet(5, 4), fake)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ScalingPhaseScalingStatefulSet, result.Scaling.Phase)
+	assert.Empty(t, fake.scaleCalls)
+
+	result, err = Step(ctx, osc, statefulSet(5, 5), fake)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), result.Replicas)
+	assert.Equal(t, v1alpha1.ScalingPhaseAwaitingChange, result.Scaling.Phase)
+	assert.Equal(t, int64(42), result.Scaling.ChangeID)
+	assert.Equal(t, [][]management.BrokerId{{0, 1, 2, 3, 4}}, fake.scaleCalls)
+
+	// The operation waits for the topology change.
+	osc.Status.Scaling = result.Scaling
+	fake.topology.PendingChange = management.TopologyChange{ID: 42, Pending: []management.Operation{{}}}
+	result, err = Step(ctx, osc, statefulSet(5, 5), fake)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ScalingPhaseAwaitingChange, result.Scaling.Phase)
+
+	// And completes once the brokers joined.
+	fake.topology = topology(5)
+	result, err = Step(ctx, osc, statefulSet(5, 5), fake)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Replicas: 5}, result)
+	assert.Len(t, fake.scaleCalls, 1)
+}
+
+func TestStep_ScaleDown(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCluster{topology: topology(5)}
+
+	// The brokers leave the topology before the StatefulSet is shrunk.
+	osc := cluster(3, nil)
+	result, err := Step(ctx, osc, statefulSet(5, 5), fake)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), result.Replicas)
+	assert.Equal(t, v1alpha1.ScalingPhaseAwaitingChange, result.Scaling.Phase)
+	assert.Equal(t, [][]management.BrokerId{{0, 1, 2}}, fake.scaleCalls)
+
+	osc.Status.Scaling = result.Scaling
+	fake.topology.PendingChange = management.TopologyChange{ID: 42, Pending: []management.Operation{{}}}
+	result, err = Step(ctx, osc, statefulSet(5, 5), fake)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), result.Replicas)
+
+	// Once the change completed, the StatefulSet is shrunk.
+	fake.topology = topology(3)
+	fake.topology.Brokers = append(fake.topology.Brokers, management.BrokerState{ID: 3, State: management.BrokerStateLeft})
+	result, err = Step(ctx, osc, statefulSet(5, 5), fake)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), result.Replicas)
+	assert.Equal(t, v1alpha1.ScalingPhaseShrinkingStatefulSet, result.Scaling.Phase)
+
+	osc.Status.Scaling = result.Scaling
+	result, err = Step(ctx, osc, statefulSet(3, 3), fake)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Replicas: 3}, result)
+}
+
+func TestStep_Resume(t *testing.T) {
+	t.Run("adopts pending change after restart", func(t *testing.T) {
+		fake := &fakeCluster{topology: topology(5)}
+		fake.topology.PendingChange = management.TopologyChange{ID: 7, Pending: []management.Operation{{}}}
+		osc := cluster(3, &v1alpha1.ScalingStatus{
+			Phase:           v1alpha1.ScalingPhaseRequestingChange,
+			FromClusterSize: 5,
+			ToClusterSize:   3,
+		})
+
+		result, err := Step(context.Background(), osc, statefulSet(5, 5), fake)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), result.Scaling.ChangeID)
+		assert.Equal(t, v1alpha1.ScalingPhaseAwaitingChange, result.Scaling.Phase)
+		assert.Empty(t, fake.scaleCalls)
+	})
+
+	t.Run("retries failed change", func(t *testing.T) {
+		fake := &fakeCluster{topology: topology(3)}
+		fake.topology.LastChange = management.CompletedChange{ID: 42, Status: "FAILED"}
+		osc := cluster(5, &v1alpha1.ScalingStatus{
+			Phase:           v1alpha1.ScalingPhaseAwaitingChange,
+			FromClusterSize: 3,
+			ToClusterSize:   5,
+			ChangeID:        42,
+		})
+
+		result, err := Step(context.Background(), osc, statefulSet(5, 5), fake)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ScalingPhaseRequestingChange, result.Scaling.Phase)
+		assert.Contains(t, result.Scaling.Message, "FAILED")
+	})
+
+	t.Run("cancels scale up before brokers joined", func(t *testing.T) {
+		osc := cluster(3, &v1alpha1.ScalingStatus{
+			Phase:           v1alpha1.ScalingPhaseScalingStatefulSet,
+			FromClusterSize: 3,
+			ToClusterSize:   5,
+		})
+
+		result, err := Step(context.Background(), osc, statefulSet(5, 3), nil)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Replicas: 3}, result)
+	})
+
+	t.Run("returns management API errors", func(t *testing.T) {
+		fake := &fakeCluster{topology: topology(3), scaleErr: errors.New("boom")}
+		osc := cluster(5, &v1alpha1.ScalingStatus{
+			Phase:           v1alpha1.ScalingPhaseRequestingChange,
+			FromClusterSize: 3,
+			ToClusterSize:   5,
+		})
+
+		_, err := Step(context.Background(), osc, statefulSet(5, 5), fake)
+		assert.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestStep_WaitsForUpgrade(t *testing.T) {
+	osc := cluster(5, nil)
+	osc.Status.Upgrade = &v1alpha1.UpgradeStatus{FromVersion: "8.7.7", ToVersion: "8.8.0", Partition: 2}
+
+	result, err := Step(context.Background(), osc, statefulSet(3, 3), nil)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Replicas: 3, RequeueAfter: PollInterval}, result)
+}
@@ -0,0 +1,173 @@
+// Package snapshot is a client for the snapshot API of Elasticsearch and OpenSearch. It is used to
+// snapshot the exported records with a backup and to restore the snapshots of a backup.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// States of a snapshot.
+const (
+	StateInProgress = "IN_PROGRESS"
+	StateSuccess    = "SUCCESS"
+	StateFailed     = "FAILED"
+	StatePartial    = "PARTIAL"
+)
+
+// Snapshot is a snapshot in a repository.
+type Snapshot struct {
+	Snapshot string   `json:"snapshot"`
+	State    string   `json:"state"`
+	Indices  []string `json:"indices,omitempty"`
+}
+
+// Failed reports whether the snapshot ended without all shards being stored.
+func (s Snapshot) Failed() bool {
+	return s.State == StateFailed || s.State == StatePartial
+}
+
+// Client talks to the snapshot API of a database.
+type Client struct {
+	httpClient *http.Client
+	baseURL    url.URL
+	username   string
+	password   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBasicAuth authenticates the requests with the user name and password.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithTLSConfig sets the TLS configuration, e.g. a custom CA or a client certificate.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = config
+		c.httpClient = &http.Client{Transport: transport}
+	}
+}
+
+// NewClient returns a client for the database at the base URL, e.g. http://elasticsearch:9200.
+func NewClient(baseURL url.URL, opts ...Option) *Client {
+	c := &Client{httpClient: &http.Client{}, baseURL: baseURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateSnapshot starts a snapshot of the indices matching the pattern without waiting for it.
+func (c *Client) CreateSnapshot(ctx context.Context, repository, name, indices string) error {
+	body := map[string]any{
+		"indices":              indices,
+		"ignore_unavailable":   true,
+		"include_global_state": false,
+	}
+	return c.do(ctx, http.MethodPut, c.url(nil, "_snapshot", repository, name), body, nil)
+}
+
+// Snapshots returns the snapshots in the repository matching the comma separated patterns.
+func (c *Client) Snapshots(ctx context.Context, repository, patterns string) ([]Snapshot, error) {
+	query := url.Values{}
+	query.Set("ignore_unavailable", "true")
+	var response struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.url(query, "_snapshot", repository, patterns), nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Snapshots, nil
+}
+
+// RestoreSnapshot starts to restore all indices of the snapshot. The indices must not exist.
+func (c *Client) RestoreSnapshot(ctx context.Context, repository, name string) error {
+	body := map[string]any{
+		"include_global_state": false,
+	}
+	return c.do(ctx, http.MethodPost, c.url(nil, "_snapshot", repository, name, "_restore"), body, nil)
+}
+
+// DeleteSnapshot deletes the snapshots matching the comma separated patterns. Deleting a snapshot
+// that does not exist succeeds, deleting from a repository that does not exist fails.
+func (c *Client) DeleteSnapshot(ctx context.Context, repository, patterns string) error {
+	err := c.do(ctx, http.MethodDelete, c.url(nil, "_snapshot", repository, patterns), nil, nil)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound &&
+		strings.Contains(statusErr.message, "snapshot_missing_exception") {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) url(query url.Values, elem ...string) url.URL {
+	u := c.baseURL
+	u.Path, _ = url.JoinPath(u.Path, elem...)
+	u.RawQuery = query.Encode()
+	return u
+}
+
+func (c *Client) do(ctx context.Context, method string, u url.URL, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return &statusError{method: method, path: u.Path, code: res.StatusCode, message: string(message)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// statusError is returned for responses with an unsuccessful status code.
+type statusError struct {
+	method  string
+	path    string
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s: received status code %d: %s", e.method, e.path, e.code, e.message)
+}
@@ -0,0 +1,79 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var requests []string
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "elastic", user)
+		assert.Equal(t, "secret", password)
+
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"snapshots":[{"snapshot":"camunda_webapps_1_8.8.0_part_1_of_1","state":"SUCCESS"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"accepted":true}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(*baseURL, WithBasicAuth("elastic", "secret"))
+
+	require.NoError(t, client.CreateSnapshot(t.Context(), "backups", "records_1", "zeebe-record*"))
+	snapshots, err := client.Snapshots(t.Context(), "backups", "camunda_webapps_1_*,records_1")
+	require.NoError(t, err)
+	require.NoError(t, client.RestoreSnapshot(t.Context(), "backups", "records_1"))
+	require.NoError(t, client.DeleteSnapshot(t.Context(), "backups", "records_1"))
+
+	assert.Equal(t, []string{
+		"PUT /_snapshot/backups/records_1",
+		"GET /_snapshot/backups/camunda_webapps_1_%2A,records_1?ignore_unavailable=true",
+		"POST /_snapshot/backups/records_1/_restore",
+		"DELETE /_snapshot/backups/records_1",
+	}, requests)
+	assert.Equal(t, "zeebe-record*", bodies[0]["indices"])
+	assert.Equal(t, false, bodies[2]["include_global_state"])
+	assert.Equal(t, []Snapshot{{Snapshot: "camunda_webapps_1_8.8.0_part_1_of_1", State: StateSuccess}}, snapshots)
+}
+
+func TestClient_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":"repository_missing_exception"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	_, err := NewClient(*baseURL).Snapshots(t.Context(), "missing", "*")
+	assert.ErrorContains(t, err, "repository_missing_exception")
+}
+
+func TestClient_DeleteMissingSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_snapshot/missing/records_1" {
+			http.Error(w, `{"error":"repository_missing_exception"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, `{"error":"snapshot_missing_exception"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(*baseURL)
+
+	assert.NoError(t, client.DeleteSnapshot(t.Context(), "backups", "records_1"))
+	assert.ErrorContains(t, client.DeleteSnapshot(t.Context(), "missing", "records_1"), "repository_missing_exception")
+}
@@ -0,0 +1,31 @@
+package status
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// Bounds of the interval in which a cluster that is not ready is checked again.
+const (
+	MinBackoff = 5 * time.Second
+	MaxBackoff = 5 * time.Minute
+)
+
+// Backoff returns how long to wait before checking a cluster that is not ready again, or zero if
+// it is ready. The wait is half of the time the cluster has not been ready, so it grows
+// exponentially from MinBackoff to MaxBackoff while the cluster converges and is derived from the
+// status alone, which keeps it across restarts of the operator.
+func Backoff(observed *v1alpha1.OrchestrationClusterStatus, now time.Time) time.Duration {
+	ready := meta.FindStatusCondition(observed.Conditions, v1alpha1.ConditionReady)
+	if ready != nil && ready.Status == metav1.ConditionTrue {
+		return 0
+	}
+	if ready == nil || ready.LastTransitionTime.IsZero() {
+		return MinBackoff
+	}
+	return min(max(now.Sub(ready.LastTransitionTime.Time)/2, MinBackoff), MaxBackoff)
+}
@@ -0,0 +1,67 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func TestBackoff(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		ready    *metav1.Condition
+		expected time.Duration
+	}{
+		{
+			name:     "no status yet",
+			expected: MinBackoff,
+		},
+		{
+			name: "ready",
+			ready: &metav1.Condition{
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Second)),
+			},
+			expected: 0,
+		},
+		{
+			name: "just became not ready",
+			ready: &metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Second)),
+			},
+			expected: MinBackoff,
+		},
+		{
+			name: "not ready for a minute",
+			ready: &metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			expected: 30 * time.Second,
+		},
+		{
+			name: "not ready for an hour",
+			ready: &metav1.Condition{
+				Status:             metav1.ConditionUnknown,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			expected: MaxBackoff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observed := &v1alpha1.OrchestrationClusterStatus{}
+			if tt.ready != nil {
+				tt.ready.Type = v1alpha1.ConditionReady
+				observed.Conditions = []metav1.Condition{*tt.ready}
+			}
+			assert.Equal(t, tt.expected, Backoff(observed, now))
+		})
+	}
+}
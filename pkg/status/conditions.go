@@ -0,0 +1,284 @@
+package status
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// Conditions derives the Ready, Progressing and Degraded conditions of the cluster from
+// the topology reported by the management API, the health of the replicas reported by the gateway
+// and the broker StatefulSet. replicaHealth is nil if the REST API is unavailable, and the
+// StatefulSet may be nil if it has not been created yet.
+func Conditions(
+	osc *v1alpha1.OrchestrationCluster,
+	topo *management.TopologyResponse,
+	replicaHealth *ReplicaHealth,
+	sts *appsv1.StatefulSet,
+) []metav1.Condition {
+	health := analyzeTopology(osc, topo, replicaHealth)
+	progressing := progressingCondition(osc, health, sts)
+	degraded := degradedCondition(osc, health)
+	ready := readyCondition(osc, health, sts, progressing, degraded)
+
+	return []metav1.Condition{ready, progressing, degraded}
+}
+
+// TopologyUnavailable returns the conditions to report when the topology could not be fetched.
+func TopologyUnavailable(osc *v1alpha1.OrchestrationCluster, err error) []metav1.Condition {
+	message := fmt.Sprintf("unable to fetch cluster topology: %v", err)
+	return []metav1.Condition{
+		newCondition(osc, v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonTopologyUnavailable, message),
+		newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionUnknown, v1alpha1.ReasonTopologyUnavailable, message),
+		newCondition(osc, v1alpha1.ConditionDegraded, metav1.ConditionUnknown, v1alpha1.ReasonTopologyUnavailable, message),
+	}
+}
+
+// ReconcileError returns the ReconcileError condition for a reconciliation that failed.
+func ReconcileError(osc *v1alpha1.OrchestrationCluster, reason string, err error) metav1.Condition {
+	return newCondition(osc, v1alpha1.ConditionReconcileError, metav1.ConditionTrue, reason, err.Error())
+}
+
+// Reconciled returns the ReconcileError condition for a reconciliation that succeeded.
+func Reconciled(osc *v1alpha1.OrchestrationCluster) metav1.Condition {
+	return newCondition(osc, v1alpha1.ConditionReconcileError, metav1.ConditionFalse, v1alpha1.ReasonReconciled,
+		"all resources are reconciled")
+}
+
+// TopologyHealthy returns an error describing why the topology is not healthy, or nil if all
+// brokers are active, all partitions are fully replicated and no topology change is pending.
+func TopologyHealthy(osc *v1alpha1.OrchestrationCluster, topo *management.TopologyResponse) error {
+	health := analyzeTopology(osc, topo, nil)
+	switch {
+	case len(health.missingBrokers) > 0:
+		return fmt.Errorf("brokers %v are not active members of the topology", health.missingBrokers)
+	case len(health.underReplicatedPartitions) > 0:
+		return fmt.Errorf("partitions %v have fewer than %d active replicas",
+			health.underReplicatedPartitions, osc.Spec.ReplicationFactor)
+	case health.pendingChanges > 0:
+		return fmt.Errorf("%d topology change operation(s) pending", health.pendingChanges)
+	}
+	return nil
+}
+
+// topologyHealth summarizes the cluster topology with respect to the desired spec.
+type topologyHealth struct {
+	version              int64
+	pendingChanges       int
+	missingBrokers       []int32
+	transitioningBrokers []int32
+	// underReplicatedPartitions have fewer active replicas than the replication factor.
+	underReplicatedPartitions []int32
+	// unhealthyPartitions are under-replicated, have no leader or a replica that is not healthy.
+	unhealthyPartitions []int32
+}
+
+func analyzeTopology(
+	osc *v1alpha1.OrchestrationCluster,
+	topo *management.TopologyResponse,
+	replicaHealth *ReplicaHealth,
+) topologyHealth {
+	health := topologyHealth{
+		version:        int64(topo.Version),
+		pendingChanges: len(topo.PendingChange.Pending),
+	}
+
+	activeBrokers := make(map[int32]bool, len(topo.Brokers))
+	activeReplicas := make(map[int32]int32)
+	for _, broker := range topo.Brokers {
+		switch broker.State {
+		case management.BrokerStateActive:
+			activeBrokers[int32(broker.ID)] = true
+		case management.BrokerStateJoining, management.BrokerStateLeaving:
+			health.transitioningBrokers = append(health.transitioningBrokers, int32(broker.ID))
+		}
+
+		for _, partition := range broker.Partitions {
+			if _, ok := activeReplicas[int32(partition.ID)]; !ok {
+				activeReplicas[int32(partition.ID)] = 0
+			}
+			if isActiveReplica(broker, partition) {
+				activeReplicas[int32(partition.ID)]++
+			}
+		}
+	}
+
+	// While scaling, only the brokers that are part of the cluster before and after the
+	// operation are expected to be active.
+	expectedBrokers := osc.Spec.ClusterSize
+	if scaling := osc.Status.Scaling; scaling != nil {
+		expectedBrokers = min(scaling.FromClusterSize, scaling.ToClusterSize)
+	}
+	for id := int32(0); id < expectedBrokers; id++ {
+		if !activeBrokers[id] {
+			health.missingBrokers = append(health.missingBrokers, id)
+		}
+	}
+
+	// Partition ids start at 1. Partitions without any replica in the topology are unhealthy too.
+	for id := int32(1); id <= osc.Spec.PartitionCount; id++ {
+		if _, ok := activeReplicas[id]; !ok {
+			activeReplicas[id] = 0
+		}
+	}
+	for id, replicas := range activeReplicas {
+		underReplicated := replicas < osc.Spec.ReplicationFactor
+		if underReplicated {
+			health.underReplicatedPartitions = append(health.underReplicatedPartitions, id)
+		}
+		if underReplicated || replicaHealth.unhealthy(id) {
+			health.unhealthyPartitions = append(health.unhealthyPartitions, id)
+		}
+	}
+	slices.Sort(health.underReplicatedPartitions)
+	slices.Sort(health.unhealthyPartitions)
+
+	return health
+}
+
+func progressingCondition(
+	osc *v1alpha1.OrchestrationCluster,
+	health topologyHealth,
+	sts *appsv1.StatefulSet,
+) metav1.Condition {
+	switch scaling := osc.Status.Scaling; {
+	case scaling != nil:
+		reason := v1alpha1.ReasonScalingUp
+		if scaling.ToClusterSize < scaling.FromClusterSize {
+			reason = v1alpha1.ReasonScalingDown
+		}
+		message := fmt.Sprintf("scaling from %d to %d brokers: %s",
+			scaling.FromClusterSize, scaling.ToClusterSize, scaling.Phase)
+		if scaling.Message != "" {
+			message = fmt.Sprintf("%s (%s)", message, scaling.Message)
+		}
+		return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionTrue, reason, message)
+	case osc.Status.Upgrade != nil:
+		upgrade := osc.Status.Upgrade
+		return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionTrue,
+			v1alpha1.ReasonUpgrading,
+			fmt.Sprintf("upgrading from %s to %s, brokers from ordinal %d are upgraded",
+				upgrade.FromVersion, upgrade.ToVersion, upgrade.Partition))
+	case health.pendingChanges > 0:
+		return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionTrue,
+			v1alpha1.ReasonTopologyChangePending,
+			fmt.Sprintf("%d topology change operation(s) pending", health.pendingChanges))
+	case len(health.transitioningBrokers) > 0:
+		return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionTrue,
+			v1alpha1.ReasonBrokersTransitioning,
+			fmt.Sprintf("brokers %v are joining or leaving the cluster", health.transitioningBrokers))
+	case rolloutInProgress(sts):
+		return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionTrue,
+			v1alpha1.ReasonRolloutInProgress,
+			fmt.Sprintf("%d of %d brokers updated", sts.Status.UpdatedReplicas, desiredReplicas(sts)))
+	case len(expandingVolumes(osc)) > 0:
+		return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionTrue,
+			v1alpha1.ReasonVolumesExpanding,
+			fmt.Sprintf("data volumes of brokers %v are being expanded", expandingVolumes(osc)))
+	}
+
+	return newCondition(osc, v1alpha1.ConditionProgressing, metav1.ConditionFalse,
+		v1alpha1.ReasonStable, "no topology change or rollout in progress")
+}
+
+// expandingVolumes returns the brokers whose data volume has not reached the requested size yet.
+func expandingVolumes(osc *v1alpha1.OrchestrationCluster) []int32 {
+	var brokers []int32
+	for _, volume := range osc.Status.Volumes {
+		if volume.Expansion != v1alpha1.VolumeExpansionCompleted {
+			brokers = append(brokers, volume.Broker)
+		}
+	}
+	return brokers
+}
+
+func degradedCondition(osc *v1alpha1.OrchestrationCluster, health topologyHealth) metav1.Condition {
+	switch {
+	case len(health.missingBrokers) > 0:
+		return newCondition(osc, v1alpha1.ConditionDegraded, metav1.ConditionTrue,
+			v1alpha1.ReasonBrokersMissing,
+			fmt.Sprintf("brokers %v are not active members of the topology", health.missingBrokers))
+	case len(health.underReplicatedPartitions) > 0:
+		return newCondition(osc, v1alpha1.ConditionDegraded, metav1.ConditionTrue,
+			v1alpha1.ReasonPartitionsUnhealthy,
+			fmt.Sprintf("partitions %v have fewer than %d active replicas",
+				health.underReplicatedPartitions, osc.Spec.ReplicationFactor))
+	case len(health.unhealthyPartitions) > 0:
+		return newCondition(osc, v1alpha1.ConditionDegraded, metav1.ConditionTrue,
+			v1alpha1.ReasonPartitionsUnhealthy,
+			fmt.Sprintf("partitions %v have no leader or unhealthy replicas", health.unhealthyPartitions))
+	}
+
+	return newCondition(osc, v1alpha1.ConditionDegraded, metav1.ConditionFalse,
+		v1alpha1.ReasonHealthy, "all brokers and partitions are active")
+}
+
+func readyCondition(
+	osc *v1alpha1.OrchestrationCluster,
+	health topologyHealth,
+	sts *appsv1.StatefulSet,
+	progressing, degraded metav1.Condition,
+) metav1.Condition {
+	notReady := func(reason, message string) metav1.Condition {
+		return newCondition(osc, v1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+	}
+
+	switch {
+	case health.version <= 0:
+		return notReady(v1alpha1.ReasonTopologyNotInitialized, "cluster topology is not initialized yet")
+	case degraded.Status == metav1.ConditionTrue:
+		return notReady(degraded.Reason, degraded.Message)
+	case progressing.Status == metav1.ConditionTrue:
+		return notReady(progressing.Reason, progressing.Message)
+	case sts == nil || sts.Status.ReadyReplicas < osc.Spec.ClusterSize:
+		var ready int32
+		if sts != nil {
+			ready = sts.Status.ReadyReplicas
+		}
+		return notReady(v1alpha1.ReasonBrokersNotReady,
+			fmt.Sprintf("%d of %d brokers are ready", ready, osc.Spec.ClusterSize))
+	}
+
+	return newCondition(osc, v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonClusterReady,
+		fmt.Sprintf("%d brokers and %d partitions are ready", osc.Spec.ClusterSize, osc.Spec.PartitionCount))
+}
+
+func rolloutInProgress(sts *appsv1.StatefulSet) bool {
+	if sts == nil {
+		return false
+	}
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return true
+	}
+	if sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		return true
+	}
+	return sts.Status.UpdatedReplicas < desiredReplicas(sts)
+}
+
+func desiredReplicas(sts *appsv1.StatefulSet) int32 {
+	if sts.Spec.Replicas == nil {
+		return 1
+	}
+	return *sts.Spec.Replicas
+}
+
+func newCondition(
+	osc *v1alpha1.OrchestrationCluster,
+	conditionType string,
+	status metav1.ConditionStatus,
+	reason, message string,
+) metav1.Condition {
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: osc.Generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
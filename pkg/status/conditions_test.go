@@ -0,0 +1,351 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	"github.com/sijoma/camunda-go-sdk/orchestration"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func cluster() *v1alpha1.OrchestrationCluster {
+	return &v1alpha1.OrchestrationCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "camunda", Generation: 2},
+		Spec: v1alpha1.OrchestrationClusterSpec{
+			ClusterSize:       3,
+			PartitionCount:    3,
+			ReplicationFactor: 3,
+		},
+	}
+}
+
+// healthyTopology returns a topology where every broker replicates every partition.
+func healthyTopology() *management.TopologyResponse {
+	topo := &management.TopologyResponse{Version: 1}
+	for broker := 0; broker < 3; broker++ {
+		state := management.BrokerState{ID: management.BrokerId(broker), State: management.BrokerStateActive}
+		for partition := 1; partition <= 3; partition++ {
+			state.Partitions = append(state.Partitions, management.PartitionState{
+				ID:    management.PartitionId(partition),
+				State: management.PartitionStateActive,
+			})
+		}
+		topo.Brokers = append(topo.Brokers, state)
+	}
+	return topo
+}
+
+// healthyReplicas returns the health the gateway reports for healthyTopology, where broker n leads
+// partition n+1 and every replica is healthy.
+func healthyReplicas() *ReplicaHealth {
+	return NewReplicaHealth(gatewayTopology(func(broker, partition int) (string, string) {
+		if partition == broker+1 {
+			return "leader", "healthy"
+		}
+		return "follower", "healthy"
+	}))
+}
+
+// gatewayTopology returns a topology of the REST API of the gateway with three brokers that
+// replicate three partitions in the role and health returned by replica.
+func gatewayTopology(replica func(broker, partition int) (role, health string)) *orchestration.TopologyResponse {
+	type partitionJSON struct {
+		PartitionID int    `json:"partitionId"`
+		Role        string `json:"role"`
+		Health      string `json:"health"`
+	}
+	type brokerJSON struct {
+		NodeID     int             `json:"nodeId"`
+		Partitions []partitionJSON `json:"partitions"`
+	}
+	var brokers []brokerJSON
+	for broker := 0; broker < 3; broker++ {
+		b := brokerJSON{NodeID: broker}
+		for partition := 1; partition <= 3; partition++ {
+			role, health := replica(broker, partition)
+			b.Partitions = append(b.Partitions, partitionJSON{PartitionID: partition, Role: role, Health: health})
+		}
+		brokers = append(brokers, b)
+	}
+	// The brokers of the response are anonymous structs, so the topology is decoded like the
+	// response of the gateway.
+	data, err := json.Marshal(map[string]any{"brokers": brokers})
+	if err != nil {
+		panic(err)
+	}
+	topo := &orchestration.TopologyResponse{}
+	if err := json.Unmarshal(data, topo); err != nil {
+		panic(err)
+	}
+	return topo
+}
+
+func readyStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3)},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:   3,
+			UpdatedReplicas: 3,
+			CurrentRevision: "rev-1",
+			UpdateRevision:  "rev-1",
+		},
+	}
+}
+
+func TestConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology func() *management.TopologyResponse
+		// replicas is the health reported by the gateway, it is unknown if not set.
+		replicas    func() *ReplicaHealth
+		sts         func() *appsv1.StatefulSet
+		ready       metav1.ConditionStatus
+		readyReason string
+		progressing metav1.ConditionStatus
+		degraded    metav1.ConditionStatus
+	}{
+		{
+			name:        "healthy cluster",
+			topology:    healthyTopology,
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionTrue,
+			readyReason: v1alpha1.ReasonClusterReady,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "topology not initialized",
+			topology: func() *management.TopologyResponse {
+				topo := healthyTopology()
+				topo.Version = 0
+				return topo
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonTopologyNotInitialized,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "pending topology change",
+			topology: func() *management.TopologyResponse {
+				topo := healthyTopology()
+				topo.PendingChange.Pending = []management.Operation{{Operation: "BROKER_ADD", BrokerId: 3}}
+				return topo
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonTopologyChangePending,
+			progressing: metav1.ConditionTrue,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "broker joining",
+			topology: func() *management.TopologyResponse {
+				topo := healthyTopology()
+				topo.Brokers = append(topo.Brokers, management.BrokerState{ID: 3, State: management.BrokerStateJoining})
+				return topo
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonBrokersTransitioning,
+			progressing: metav1.ConditionTrue,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name:     "statefulset rolling out",
+			topology: healthyTopology,
+			sts: func() *appsv1.StatefulSet {
+				sts := readyStatefulSet()
+				sts.Status.UpdateRevision = "rev-2"
+				sts.Status.UpdatedReplicas = 1
+				return sts
+			},
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonRolloutInProgress,
+			progressing: metav1.ConditionTrue,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "broker missing from topology",
+			topology: func() *management.TopologyResponse {
+				topo := healthyTopology()
+				topo.Brokers = topo.Brokers[:2]
+				return topo
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonBrokersMissing,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionTrue,
+		},
+		{
+			name: "under-replicated partition",
+			topology: func() *management.TopologyResponse {
+				topo := healthyTopology()
+				topo.Brokers[1].Partitions[2].State = management.PartitionStateJoining
+				return topo
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonPartitionsUnhealthy,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionTrue,
+		},
+		{
+			name:        "healthy replicas",
+			topology:    healthyTopology,
+			replicas:    healthyReplicas,
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionTrue,
+			readyReason: v1alpha1.ReasonClusterReady,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name:     "partition without leader",
+			topology: healthyTopology,
+			replicas: func() *ReplicaHealth {
+				return NewReplicaHealth(gatewayTopology(func(int, int) (string, string) {
+					return "follower", "healthy"
+				}))
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonPartitionsUnhealthy,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionTrue,
+		},
+		{
+			name:     "unhealthy replica",
+			topology: healthyTopology,
+			replicas: func() *ReplicaHealth {
+				return NewReplicaHealth(gatewayTopology(func(broker, partition int) (string, string) {
+					if partition == broker+1 {
+						return "leader", "healthy"
+					}
+					if broker == 2 && partition == 1 {
+						return "follower", "unhealthy"
+					}
+					return "follower", "healthy"
+				}))
+			},
+			sts:         readyStatefulSet,
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonPartitionsUnhealthy,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionTrue,
+		},
+		{
+			name:     "pods not ready",
+			topology: healthyTopology,
+			sts: func() *appsv1.StatefulSet {
+				sts := readyStatefulSet()
+				sts.Status.ReadyReplicas = 2
+				return sts
+			},
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonBrokersNotReady,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name:        "statefulset not created yet",
+			topology:    healthyTopology,
+			sts:         func() *appsv1.StatefulSet { return nil },
+			ready:       metav1.ConditionFalse,
+			readyReason: v1alpha1.ReasonBrokersNotReady,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osc := cluster()
+			var replicas *ReplicaHealth
+			if tt.replicas != nil {
+				replicas = tt.replicas()
+			}
+			conditions := Conditions(osc, tt.topology(), replicas, tt.sts())
+
+			ready := meta.FindStatusCondition(conditions, v1alpha1.ConditionReady)
+			progressing := meta.FindStatusCondition(conditions, v1alpha1.ConditionProgressing)
+			degraded := meta.FindStatusCondition(conditions, v1alpha1.ConditionDegraded)
+
+			assert.NotNil(t, ready)
+			assert.NotNil(t, progressing)
+			assert.NotNil(t, degraded)
+			assert.Equal(t, tt.ready, ready.Status)
+			assert.Equal(t, tt.readyReason, ready.Reason)
+			assert.Equal(t, tt.progressing, progressing.Status)
+			assert.Equal(t, tt.degraded, degraded.Status)
+			for _, condition := range conditions {
+				assert.Equal(t, osc.Generation, condition.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestTopologyUnavailable(t *testing.T) {
+	conditions := TopologyUnavailable(cluster(), errors.New("connection refused"))
+
+	ready := meta.FindStatusCondition(conditions, v1alpha1.ConditionReady)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, v1alpha1.ReasonTopologyUnavailable, ready.Reason)
+	assert.Contains(t, ready.Message, "connection refused")
+
+	for _, conditionType := range []string{v1alpha1.ConditionProgressing, v1alpha1.ConditionDegraded} {
+		condition := meta.FindStatusCondition(conditions, conditionType)
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	}
+}
+
+func TestConditions_Scaling(t *testing.T) {
+	osc := cluster()
+	osc.Spec.ClusterSize = 5
+	osc.Status.Scaling = &v1alpha1.ScalingStatus{
+		Phase:           v1alpha1.ScalingPhaseAwaitingChange,
+		FromClusterSize: 3,
+		ToClusterSize:   5,
+	}
+
+	conditions := Conditions(osc, healthyTopology(), healthyReplicas(), readyStatefulSet())
+
+	progressing := meta.FindStatusCondition(conditions, v1alpha1.ConditionProgressing)
+	assert.Equal(t, metav1.ConditionTrue, progressing.Status)
+	assert.Equal(t, v1alpha1.ReasonScalingUp, progressing.Reason)
+	assert.Contains(t, progressing.Message, "AwaitingChange")
+
+	// The brokers that did not join yet are not reported as missing.
+	degraded := meta.FindStatusCondition(conditions, v1alpha1.ConditionDegraded)
+	assert.Equal(t, metav1.ConditionFalse, degraded.Status)
+
+	ready := meta.FindStatusCondition(conditions, v1alpha1.ConditionReady)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, v1alpha1.ReasonScalingUp, ready.Reason)
+}
+
+func TestConditions_VolumeExpansion(t *testing.T) {
+	osc := cluster()
+	osc.Status.Volumes = []v1alpha1.VolumeStatus{
+		{Broker: 0, Expansion: v1alpha1.VolumeExpansionCompleted},
+		{Broker: 1, Expansion: v1alpha1.VolumeExpansionFileSystemResizePending},
+		{Broker: 2, Expansion: v1alpha1.VolumeExpansionResizing},
+	}
+
+	conditions := Conditions(osc, healthyTopology(), healthyReplicas(), readyStatefulSet())
+
+	progressing := meta.FindStatusCondition(conditions, v1alpha1.ConditionProgressing)
+	assert.Equal(t, metav1.ConditionTrue, progressing.Status)
+	assert.Equal(t, v1alpha1.ReasonVolumesExpanding, progressing.Reason)
+	assert.Equal(t, "data volumes of brokers [1 2] are being expanded", progressing.Message)
+}
@@ -0,0 +1,77 @@
+package status
+
+import (
+	"github.com/sijoma/camunda-go-sdk/orchestration"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// replicaHealthy is the health the gateway reports for replicas that are healthy.
+const replicaHealthy = "healthy"
+
+// ReplicaHealth is the role and health of the partition replicas as reported by the topology of
+// the REST API of the gateway. The topology of the management API does not report either.
+type ReplicaHealth struct {
+	replicas map[replicaKey]replica
+	leaders  map[int32]int32
+}
+
+type replicaKey struct {
+	broker, partition int32
+}
+
+type replica struct {
+	role   v1alpha1.PartitionRole
+	health string
+}
+
+// NewReplicaHealth returns the role and health of the replicas in the topology of the gateway.
+func NewReplicaHealth(topo *orchestration.TopologyResponse) *ReplicaHealth {
+	h := &ReplicaHealth{replicas: map[replicaKey]replica{}, leaders: map[int32]int32{}}
+	for _, broker := range topo.Brokers {
+		for _, partition := range broker.Partitions {
+			key := replicaKey{broker: int32(broker.NodeId), partition: int32(partition.PartitionId)}
+			role := v1alpha1.PartitionRole(partition.Role)
+			h.replicas[key] = replica{role: role, health: partition.Health}
+			if role == v1alpha1.PartitionRoleLeader {
+				h.leaders[key.partition] = key.broker
+			}
+		}
+	}
+	return h
+}
+
+// replica returns the role and health of the replica of the partition on the broker.
+func (h *ReplicaHealth) replica(broker, partition int32) (replica, bool) {
+	if h == nil {
+		return replica{}, false
+	}
+	r, ok := h.replicas[replicaKey{broker: broker, partition: partition}]
+	return r, ok
+}
+
+// leader returns the broker leading the partition.
+func (h *ReplicaHealth) leader(partition int32) (int32, bool) {
+	if h == nil {
+		return 0, false
+	}
+	leader, ok := h.leaders[partition]
+	return leader, ok
+}
+
+// unhealthy reports whether the partition has no leader or a replica that is not healthy. Nothing
+// is unhealthy if the health is unknown.
+func (h *ReplicaHealth) unhealthy(partition int32) bool {
+	if h == nil {
+		return false
+	}
+	if _, ok := h.leaders[partition]; !ok {
+		return true
+	}
+	for key, r := range h.replicas {
+		if key.partition == partition && r.health != replicaHealthy {
+			return true
+		}
+	}
+	return false
+}
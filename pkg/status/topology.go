@@ -0,0 +1,82 @@
+package status
+
+import (
+	"sort"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+// ObserveTopology publishes the brokers, partitions and topology version reported by the
+// management API in the given status, together with the role and health of the replicas reported
+// by the gateway. replicaHealth is nil if the REST API is unavailable. Conditions are left untouched.
+func ObserveTopology(
+	status *v1alpha1.OrchestrationClusterStatus,
+	osc *v1alpha1.OrchestrationCluster,
+	topo *management.TopologyResponse,
+	replicaHealth *ReplicaHealth,
+) {
+	health := analyzeTopology(osc, topo, replicaHealth)
+	unhealthy := make(map[int32]bool, len(health.unhealthyPartitions))
+	for _, id := range health.unhealthyPartitions {
+		unhealthy[id] = true
+	}
+
+	brokers := make([]v1alpha1.BrokerStatus, 0, len(topo.Brokers))
+	partitions := map[int32]*v1alpha1.PartitionStatus{}
+	for _, broker := range topo.Brokers {
+		brokerStatus := v1alpha1.BrokerStatus{
+			ID:    int32(broker.ID),
+			State: string(broker.State),
+		}
+		for _, partition := range broker.Partitions {
+			id := int32(partition.ID)
+			replica, _ := replicaHealth.replica(int32(broker.ID), id)
+			brokerStatus.Partitions = append(brokerStatus.Partitions, v1alpha1.BrokerPartitionStatus{
+				ID:     id,
+				Role:   replica.role,
+				Health: replica.health,
+				State:  string(partition.State),
+			})
+
+			if _, ok := partitions[id]; !ok {
+				partitions[id] = &v1alpha1.PartitionStatus{ID: id}
+			}
+			if isActiveReplica(broker, partition) {
+				partitions[id].Replicas++
+			}
+		}
+		sort.Slice(brokerStatus.Partitions, func(i, j int) bool {
+			return brokerStatus.Partitions[i].ID < brokerStatus.Partitions[j].ID
+		})
+		brokers = append(brokers, brokerStatus)
+	}
+	sort.Slice(brokers, func(i, j int) bool { return brokers[i].ID < brokers[j].ID })
+
+	for id := int32(1); id <= osc.Spec.PartitionCount; id++ {
+		if _, ok := partitions[id]; !ok {
+			partitions[id] = &v1alpha1.PartitionStatus{ID: id}
+		}
+	}
+	partitionList := make([]v1alpha1.PartitionStatus, 0, len(partitions))
+	for id, partition := range partitions {
+		if leader, ok := replicaHealth.leader(id); ok {
+			partition.Leader = &leader
+		}
+		partition.Healthy = !unhealthy[id]
+		partitionList = append(partitionList, *partition)
+	}
+	sort.Slice(partitionList, func(i, j int) bool { return partitionList[i].ID < partitionList[j].ID })
+
+	status.ObservedClusterSize = int32(len(topo.Brokers))
+	status.TopologyVersion = int64(topo.Version)
+	status.PendingChanges = int32(health.pendingChanges)
+	status.UnhealthyPartitions = int32(len(health.unhealthyPartitions))
+	status.Brokers = brokers
+	status.Partitions = partitionList
+}
+
+func isActiveReplica(broker management.BrokerState, partition management.PartitionState) bool {
+	return broker.State == management.BrokerStateActive && partition.State == management.PartitionStateActive
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func TestObserveTopology(t *testing.T) {
+	t.Run("healthy cluster", func(t *testing.T) {
+		topo := healthyTopology()
+		topo.Version = 7
+
+		var observed v1alpha1.OrchestrationClusterStatus
+		ObserveTopology(&observed, cluster(), topo, healthyReplicas())
+
+		assert.Equal(t, int32(3), observed.ObservedClusterSize)
+		assert.Equal(t, int64(7), observed.TopologyVersion)
+		assert.Equal(t, int32(0), observed.PendingChanges)
+		assert.Equal(t, int32(0), observed.UnhealthyPartitions)
+
+		require.Len(t, observed.Partitions, 3)
+		for i, partition := range observed.Partitions {
+			assert.Equal(t, int32(i+1), partition.ID)
+			assert.Equal(t, ptr.To(int32(i)), partition.Leader)
+			assert.Equal(t, int32(3), partition.Replicas)
+			assert.True(t, partition.Healthy)
+		}
+
+		require.Len(t, observed.Brokers, 3)
+		broker := observed.Brokers[1]
+		assert.Equal(t, int32(1), broker.ID)
+		assert.Equal(t, "ACTIVE", broker.State)
+		require.Len(t, broker.Partitions, 3)
+		assert.Equal(t, v1alpha1.PartitionRoleFollower, broker.Partitions[0].Role)
+		assert.Equal(t, v1alpha1.PartitionRoleLeader, broker.Partitions[1].Role)
+		assert.Equal(t, v1alpha1.PartitionRoleFollower, broker.Partitions[2].Role)
+		assert.Equal(t, "healthy", broker.Partitions[1].Health)
+	})
+
+	t.Run("leader and health reported by the gateway", func(t *testing.T) {
+		// The replica with the highest priority does not necessarily lead.
+		topo := healthyTopology()
+		topo.Brokers[0].Partitions[0].Priority = 3
+		replicas := NewReplicaHealth(gatewayTopology(func(broker, partition int) (string, string) {
+			switch {
+			case partition == 1 && broker == 2:
+				return "leader", "healthy"
+			case partition == 2 && broker == 0:
+				return "inactive", "dead"
+			case partition == broker+1:
+				return "leader", "healthy"
+			}
+			return "follower", "healthy"
+		}))
+
+		var observed v1alpha1.OrchestrationClusterStatus
+		ObserveTopology(&observed, cluster(), topo, replicas)
+
+		assert.Equal(t, ptr.To(int32(2)), observed.Partitions[0].Leader)
+		assert.True(t, observed.Partitions[0].Healthy)
+		assert.Equal(t, ptr.To(int32(1)), observed.Partitions[1].Leader)
+		assert.False(t, observed.Partitions[1].Healthy)
+		assert.Equal(t, int32(1), observed.UnhealthyPartitions)
+		assert.Equal(t, v1alpha1.PartitionRoleInactive, observed.Brokers[0].Partitions[1].Role)
+		assert.Equal(t, "dead", observed.Brokers[0].Partitions[1].Health)
+	})
+
+	t.Run("inactive replicas are under-replicated", func(t *testing.T) {
+		topo := healthyTopology()
+		topo.Brokers[0].Partitions[0].State = management.PartitionStateJoining
+		topo.PendingChange.Pending = []management.Operation{{Operation: "PARTITION_JOIN"}}
+
+		var observed v1alpha1.OrchestrationClusterStatus
+		ObserveTopology(&observed, cluster(), topo, healthyReplicas())
+
+		assert.Equal(t, int32(1), observed.PendingChanges)
+		assert.Equal(t, int32(1), observed.UnhealthyPartitions)
+		assert.Equal(t, int32(2), observed.Partitions[0].Replicas)
+		assert.False(t, observed.Partitions[0].Healthy)
+		assert.Equal(t, "JOINING", observed.Brokers[0].Partitions[0].State)
+	})
+
+	t.Run("REST API unavailable", func(t *testing.T) {
+		var observed v1alpha1.OrchestrationClusterStatus
+		ObserveTopology(&observed, cluster(), healthyTopology(), nil)
+
+		assert.Equal(t, int32(0), observed.UnhealthyPartitions)
+		for _, partition := range observed.Partitions {
+			assert.Nil(t, partition.Leader)
+			assert.True(t, partition.Healthy)
+		}
+		assert.Empty(t, observed.Brokers[0].Partitions[0].Role)
+		assert.Empty(t, observed.Brokers[0].Partitions[0].Health)
+	})
+
+	t.Run("partitions missing from topology", func(t *testing.T) {
+		var observed v1alpha1.OrchestrationClusterStatus
+		ObserveTopology(&observed, cluster(), &management.TopologyResponse{}, nil)
+
+		assert.Equal(t, int32(0), observed.ObservedClusterSize)
+		assert.Empty(t, observed.Brokers)
+		assert.Equal(t, int32(3), observed.UnhealthyPartitions)
+		require.Len(t, observed.Partitions, 3)
+		for _, partition := range observed.Partitions {
+			assert.Nil(t, partition.Leader)
+			assert.False(t, partition.Healthy)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+const (
+	// RetainedFromAnnotation is set on data volume claims that were kept after their cluster was deleted.
+	// It holds the name and uid of the deleted cluster.
+	RetainedFromAnnotation = "core.camunda.io/retained-from"
+	// SourceClaimLabel is set on the snapshots of data volume claims.
+	SourceClaimLabel = "core.camunda.io/source-claim"
+)
+
+// VolumeSnapshotGVK is the kind of the snapshots taken with the Snapshot deletion policy.
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// DeletionPolicy returns the deletion policy of the cluster, defaulting to Retain.
+func DeletionPolicy(osc *v1alpha1.OrchestrationCluster) v1alpha1.DeletionPolicy {
+	if osc.Spec.DeletionPolicy == "" {
+		return v1alpha1.DeletionPolicyRetain
+	}
+	return osc.Spec.DeletionPolicy
+}
+
+// IsDataClaim reports whether the claim is the data volume claim of a broker of the cluster.
+func IsDataClaim(osc *v1alpha1.OrchestrationCluster, pvc *corev1.PersistentVolumeClaim) bool {
+	prefix := ClaimName(osc.Name, 0)
+	prefix = prefix[:len(prefix)-1]
+	ordinal, found := strings.CutPrefix(pvc.Name, prefix)
+	if !found || pvc.Namespace != osc.Namespace {
+		return false
+	}
+	_, err := strconv.ParseUint(ordinal, 10, 32)
+	return err == nil
+}
+
+// RetainedFrom returns the value of the RetainedFromAnnotation for claims of the cluster.
+func RetainedFrom(osc *v1alpha1.OrchestrationCluster) string {
+	return osc.Name + "/" + string(osc.UID)
+}
+
+// SnapshotName returns the name of the final snapshot of the claim. It is derived from the cluster
+// uid, so a cluster recreated with the same name does not collide with snapshots of its predecessor.
+func SnapshotName(osc *v1alpha1.OrchestrationCluster, pvc *corev1.PersistentVolumeClaim) string {
+	uid := string(osc.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return pvc.Name + "-" + uid
+}
+
+// VolumeSnapshot returns the final snapshot of the claim.
+func VolumeSnapshot(osc *v1alpha1.OrchestrationCluster, pvc *corev1.PersistentVolumeClaim) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetName(SnapshotName(osc, pvc))
+	snapshot.SetNamespace(pvc.Namespace)
+	snapshot.SetLabels(map[string]string{SourceClaimLabel: pvc.Name})
+	snapshot.SetAnnotations(map[string]string{RetainedFromAnnotation: RetainedFrom(osc)})
+
+	spec := map[string]any{
+		"source": map[string]any{
+			"persistentVolumeClaimName": pvc.Name,
+		},
+	}
+	if osc.Spec.Storage != nil && osc.Spec.Storage.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *osc.Spec.Storage.VolumeSnapshotClassName
+	}
+	snapshot.Object["spec"] = spec
+	return snapshot
+}
+
+// SnapshotReady reports whether the snapshot can be used to restore the volume, which means the
+// claim it was taken from may be deleted.
+func SnapshotReady(snapshot *unstructured.Unstructured) bool {
+	ready, found, err := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	return err == nil && found && ready
+}
+
+// SnapshotError returns the error reported by the snapshot controller, if any.
+func SnapshotError(snapshot *unstructured.Unstructured) string {
+	message, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return message
+}
+
+// RetainClaim marks the claim as retained from the cluster.
+func RetainClaim(osc *v1alpha1.OrchestrationCluster, pvc *corev1.PersistentVolumeClaim) {
+	metav1.SetMetaDataAnnotation(&pvc.ObjectMeta, RetainedFromAnnotation, RetainedFrom(osc))
+}
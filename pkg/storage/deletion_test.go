@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func deletedCluster() *v1alpha1.OrchestrationCluster {
+	return &v1alpha1.OrchestrationCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "camunda",
+			Namespace: "camunda-ns",
+			UID:       "8f2c1a4e-1b2c-4d5e-9f00-123456789abc",
+		},
+	}
+}
+
+func dataClaim(namespace, name string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func TestDeletionPolicy(t *testing.T) {
+	osc := deletedCluster()
+	assert.Equal(t, v1alpha1.DeletionPolicyRetain, DeletionPolicy(osc))
+
+	osc.Spec.DeletionPolicy = v1alpha1.DeletionPolicySnapshot
+	assert.Equal(t, v1alpha1.DeletionPolicySnapshot, DeletionPolicy(osc))
+}
+
+func TestIsDataClaim(t *testing.T) {
+	osc := deletedCluster()
+
+	assert.True(t, IsDataClaim(osc, dataClaim("camunda-ns", "data-camunda-0")))
+	assert.True(t, IsDataClaim(osc, dataClaim("camunda-ns", "data-camunda-12")))
+	assert.False(t, IsDataClaim(osc, dataClaim("other-ns", "data-camunda-0")), "Other namespace")
+	assert.False(t, IsDataClaim(osc, dataClaim("camunda-ns", "data-camunda-backup-0")), "Other cluster")
+	assert.False(t, IsDataClaim(osc, dataClaim("camunda-ns", "logs-camunda-0")), "Other volume")
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	osc := deletedCluster()
+	osc.Spec.Storage = &v1alpha1.Storage{VolumeSnapshotClassName: ptr.To("csi-snapclass")}
+
+	snapshot := VolumeSnapshot(osc, dataClaim("camunda-ns", "data-camunda-1"))
+
+	assert.Equal(t, VolumeSnapshotGVK, snapshot.GroupVersionKind())
+	assert.Equal(t, "data-camunda-1-8f2c1a4e", snapshot.GetName())
+	assert.Equal(t, "camunda-ns", snapshot.GetNamespace())
+	assert.Equal(t, "data-camunda-1", snapshot.GetLabels()[SourceClaimLabel])
+	assert.Equal(t, "camunda/8f2c1a4e-1b2c-4d5e-9f00-123456789abc", snapshot.GetAnnotations()[RetainedFromAnnotation])
+
+	claim, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, "data-camunda-1", claim)
+	class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", class)
+}
+
+func TestSnapshotReady(t *testing.T) {
+	snapshot := VolumeSnapshot(deletedCluster(), dataClaim("camunda-ns", "data-camunda-0"))
+	assert.False(t, SnapshotReady(snapshot))
+
+	_ = unstructured.SetNestedField(snapshot.Object, "failed to take snapshot", "status", "error", "message")
+	assert.Equal(t, "failed to take snapshot", SnapshotError(snapshot))
+
+	_ = unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse")
+	assert.True(t, SnapshotReady(snapshot))
+}
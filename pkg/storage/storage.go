@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+const (
+	// VolumeName is the name of the data volume claim template of the StatefulSet.
+	VolumeName = "data"
+	// PollInterval is the interval in which the progress of a volume expansion is checked.
+	PollInterval = 30 * time.Second
+)
+
+// DefaultSize is the size of the data volumes when spec.storage.size is not set.
+var DefaultSize = resource.MustParse("10Gi")
+
+// Size returns the requested size of the data volume of each broker.
+func Size(osc *v1alpha1.OrchestrationCluster) resource.Quantity {
+	if osc.Spec.Storage == nil || osc.Spec.Storage.Size == nil {
+		return DefaultSize.DeepCopy()
+	}
+	return osc.Spec.Storage.Size.DeepCopy()
+}
+
+// AccessModes returns the access modes of the data volumes.
+func AccessModes(osc *v1alpha1.OrchestrationCluster) []corev1.PersistentVolumeAccessMode {
+	if osc.Spec.Storage == nil || len(osc.Spec.Storage.AccessModes) == 0 {
+		return []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	return osc.Spec.Storage.AccessModes
+}
+
+// ClaimName returns the name of the data volume claim the StatefulSet creates for the broker.
+func ClaimName(statefulSetName string, broker int32) string {
+	return fmt.Sprintf("%s-%s-%d", VolumeName, statefulSetName, broker)
+}
+
+// NeedsExpansion reports whether the claim must be resized to the requested size.
+// Claims that are not bound yet cannot be resized.
+func NeedsExpansion(pvc *corev1.PersistentVolumeClaim, size resource.Quantity) bool {
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return false
+	}
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	return requested.Cmp(size) < 0
+}
+
+// Observe returns the status of the data volume of the broker. The resize error is the error of
+// the last attempt to resize the claim, if any.
+func Observe(
+	broker int32,
+	pvc *corev1.PersistentVolumeClaim,
+	size resource.Quantity,
+	resizeErr error,
+) v1alpha1.VolumeStatus {
+	volume := v1alpha1.VolumeStatus{
+		Broker:    broker,
+		ClaimName: pvc.Name,
+		Expansion: v1alpha1.VolumeExpansionCompleted,
+	}
+	capacity, bound := pvc.Status.Capacity[corev1.ResourceStorage]
+	if bound {
+		volume.Capacity = &capacity
+	}
+
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch {
+	case resizeErr != nil:
+		volume.Expansion = v1alpha1.VolumeExpansionPending
+		volume.Message = fmt.Sprintf("failed to resize claim to %s: %v", size.String(), resizeErr)
+	case pvc.Status.Phase != corev1.ClaimBound:
+		volume.Expansion = v1alpha1.VolumeExpansionPending
+		volume.Message = "waiting for the claim to be bound"
+	case requested.Cmp(size) < 0:
+		volume.Expansion = v1alpha1.VolumeExpansionPending
+		volume.Message = fmt.Sprintf("waiting for the claim to be resized to %s", size.String())
+	case hasCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending):
+		volume.Expansion = v1alpha1.VolumeExpansionFileSystemResizePending
+		volume.Message = conditionMessage(pvc, corev1.PersistentVolumeClaimFileSystemResizePending,
+			"waiting for the file system to be resized")
+	case capacity.Cmp(size) < 0:
+		volume.Expansion = v1alpha1.VolumeExpansionResizing
+		volume.Message = conditionMessage(pvc, corev1.PersistentVolumeClaimResizing,
+			fmt.Sprintf("resizing volume from %s to %s", capacity.String(), size.String()))
+	}
+	return volume
+}
+
+// Expanding reports whether any volume has not reached the requested size yet.
+func Expanding(volumes []v1alpha1.VolumeStatus) bool {
+	for _, volume := range volumes {
+		if volume.Expansion != v1alpha1.VolumeExpansionCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCondition(pvc *corev1.PersistentVolumeClaim, conditionType corev1.PersistentVolumeClaimConditionType) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionMessage(
+	pvc *corev1.PersistentVolumeClaim,
+	conditionType corev1.PersistentVolumeClaimConditionType,
+	fallback string,
+) string {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == conditionType && condition.Message != "" {
+			return condition.Message
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+func claim(requested, capacity string, conditions ...corev1.PersistentVolumeClaimCondition) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-camunda-0"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(requested)},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:      corev1.ClaimBound,
+			Capacity:   corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			Conditions: conditions,
+		},
+	}
+}
+
+func TestSize(t *testing.T) {
+	osc := &v1alpha1.OrchestrationCluster{}
+	assert.Equal(t, "10Gi", ptr.To(Size(osc)).String())
+
+	osc.Spec.Storage = &v1alpha1.Storage{Size: ptr.To(resource.MustParse("50Gi"))}
+	assert.Equal(t, "50Gi", ptr.To(Size(osc)).String())
+}
+
+func TestAccessModes(t *testing.T) {
+	osc := &v1alpha1.OrchestrationCluster{}
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, AccessModes(osc))
+
+	osc.Spec.Storage = &v1alpha1.Storage{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod}}
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod}, AccessModes(osc))
+}
+
+func TestClaimName(t *testing.T) {
+	assert.Equal(t, "data-camunda-2", ClaimName("camunda", 2))
+}
+
+func TestNeedsExpansion(t *testing.T) {
+	size := resource.MustParse("20Gi")
+
+	assert.True(t, NeedsExpansion(claim("10Gi", "10Gi"), size))
+	assert.False(t, NeedsExpansion(claim("20Gi", "10Gi"), size), "Resize was already requested")
+	assert.False(t, NeedsExpansion(claim("30Gi", "30Gi"), size), "Volumes are never shrunk")
+
+	pending := claim("10Gi", "10Gi")
+	pending.Status.Phase = corev1.ClaimPending
+	assert.False(t, NeedsExpansion(pending, size), "Unbound claims cannot be resized")
+}
+
+func TestObserve(t *testing.T) {
+	size := resource.MustParse("20Gi")
+
+	tests := []struct {
+		name      string
+		pvc       *corev1.PersistentVolumeClaim
+		resizeErr error
+		expansion v1alpha1.VolumeExpansionPhase
+		message   string
+	}{
+		{
+			name:      "completed",
+			pvc:       claim("20Gi", "20Gi"),
+			expansion: v1alpha1.VolumeExpansionCompleted,
+		},
+		{
+			name:      "resize not requested yet",
+			pvc:       claim("10Gi", "10Gi"),
+			expansion: v1alpha1.VolumeExpansionPending,
+			message:   "waiting for the claim to be resized to 20Gi",
+		},
+		{
+			name:      "resize rejected",
+			pvc:       claim("10Gi", "10Gi"),
+			resizeErr: errors.New("storageclass does not allow volume expansion"),
+			expansion: v1alpha1.VolumeExpansionPending,
+			message:   "failed to resize claim to 20Gi: storageclass does not allow volume expansion",
+		},
+		{
+			name:      "resizing",
+			pvc:       claim("20Gi", "10Gi"),
+			expansion: v1alpha1.VolumeExpansionResizing,
+			message:   "resizing volume from 10Gi to 20Gi",
+		},
+		{
+			name: "file system resize pending",
+			pvc: claim("20Gi", "10Gi", corev1.PersistentVolumeClaimCondition{
+				Type:    corev1.PersistentVolumeClaimFileSystemResizePending,
+				Status:  corev1.ConditionTrue,
+				Message: "Waiting for user to (re-)start a pod to finish file system resize of volume on node.",
+			}),
+			expansion: v1alpha1.VolumeExpansionFileSystemResizePending,
+			message:   "Waiting for user to (re-)start a pod to finish file system resize of volume on node.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volume := Observe(1, tt.pvc, size, tt.resizeErr)
+
+			assert.Equal(t, int32(1), volume.Broker)
+			assert.Equal(t, "data-camunda-0", volume.ClaimName)
+			assert.Equal(t, tt.expansion, volume.Expansion)
+			assert.Equal(t, tt.message, volume.Message)
+		})
+	}
+}
+
+func TestExpanding(t *testing.T) {
+	assert.False(t, Expanding(nil))
+	assert.False(t, Expanding([]v1alpha1.VolumeStatus{{Expansion: v1alpha1.VolumeExpansionCompleted}}))
+	assert.True(t, Expanding([]v1alpha1.VolumeStatus{
+		{Expansion: v1alpha1.VolumeExpansionCompleted},
+		{Expansion: v1alpha1.VolumeExpansionResizing},
+	}))
+}
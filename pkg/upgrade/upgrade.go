@@ -0,0 +1,207 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+	"github.com/camunda/camunda-operator/pkg/bundles"
+	"github.com/camunda/camunda-operator/pkg/status"
+)
+
+const (
+	// PollInterval is the interval in which the progress of an upgrade is checked.
+	PollInterval = 10 * time.Second
+	// BrokerRejoinTimeout is the time an upgraded broker has to rejoin the cluster before the
+	// upgrade is reported as blocked.
+	BrokerRejoinTimeout = 10 * time.Minute
+)
+
+// versionLabel is the label of the StatefulSet holding the version it was created with.
+const versionLabel = "app.kubernetes.io/version"
+
+// Cluster is the part of the Zeebe cluster management API needed to upgrade brokers.
+// It is implemented by management.Cluster.
+type Cluster interface {
+	Topology(ctx context.Context) (*management.TopologyResponse, error)
+}
+
+// Result is the outcome of a single step of a version upgrade.
+type Result struct {
+	// Version is the version the cluster resources must be built with.
+	Version string
+	// RunningVersion is the version all brokers are running.
+	RunningVersion string
+	// Partition is the partition of the StatefulSet rolling update.
+	// It is nil if no upgrade is in progress.
+	Partition *int32
+	// Upgrade is the upgrade status to persist. It is nil if no upgrade is in progress.
+	Upgrade *v1alpha1.UpgradeStatus
+	// Blocked is the UpgradeBlocked condition.
+	Blocked metav1.Condition
+	// RequeueAfter is set while the upgrade is waiting for a broker.
+	RequeueAfter time.Duration
+}
+
+// Step advances the rolling upgrade of the cluster to spec.version by one step.
+//
+// Brokers are upgraded one at a time, starting with the highest ordinal. The next broker is only
+// upgraded once the previous one is ready and the cluster topology is healthy again. The upgrade
+// path is validated first, an unsupported upgrade keeps the cluster on its running version.
+// The StatefulSet is nil if it has not been created yet.
+func Step(
+	ctx context.Context,
+	osc *v1alpha1.OrchestrationCluster,
+	sts *appsv1.StatefulSet,
+	cluster Cluster,
+	now time.Time,
+) (Result, error) {
+	target := osc.Spec.Version
+	if target == "" {
+		target = bundles.DefaultVersion
+	}
+	running := runningVersion(osc, sts)
+	upgrade := osc.Status.Upgrade.DeepCopy()
+
+	if upgrade == nil {
+		if running == target {
+			return Result{Version: target, RunningVersion: target, Blocked: notBlocked(osc)}, nil
+		}
+		if err := bundles.ValidateUpgrade(running, target); err != nil {
+			return Result{
+				Version:        running,
+				RunningVersion: running,
+				Blocked:        blocked(osc, v1alpha1.ReasonInvalidUpgradePath, err.Error()),
+			}, nil
+		}
+		if osc.Status.Scaling != nil {
+			// The upgrade starts once the brokers are scaled.
+			return Result{
+				Version:        running,
+				RunningVersion: running,
+				Blocked:        notBlocked(osc),
+				RequeueAfter:   PollInterval,
+			}, nil
+		}
+
+		upgrade = &v1alpha1.UpgradeStatus{
+			FromVersion:     running,
+			ToVersion:       target,
+			Partition:       replicas(sts) - 1,
+			BrokerUpdatedAt: metav1.NewTime(now),
+			StartedAt:       metav1.NewTime(now),
+		}
+		return inProgress(upgrade, notBlocked(osc)), nil
+	}
+
+	condition := notBlocked(osc)
+	if target != upgrade.ToVersion {
+		condition = blocked(osc, v1alpha1.ReasonUpgradeTargetChanged,
+			fmt.Sprintf("upgrade from %s to %s is in progress, it must complete before upgrading to %s",
+				upgrade.FromVersion, upgrade.ToVersion, target))
+	}
+
+	if err := brokerRejoined(ctx, osc, sts, cluster, upgrade.Partition); err != nil {
+		if now.Sub(upgrade.BrokerUpdatedAt.Time) > BrokerRejoinTimeout {
+			condition = blocked(osc, v1alpha1.ReasonBrokerNotRejoined,
+				fmt.Sprintf("broker %d did not rejoin the cluster within %s after upgrading to %s: %v",
+					upgrade.Partition, BrokerRejoinTimeout, upgrade.ToVersion, err))
+		}
+		return inProgress(upgrade, condition), nil
+	}
+
+	if upgrade.Partition > 0 {
+		upgrade.Partition--
+		upgrade.BrokerUpdatedAt = metav1.NewTime(now)
+		return inProgress(upgrade, condition), nil
+	}
+
+	return Result{Version: upgrade.ToVersion, RunningVersion: upgrade.ToVersion, Blocked: condition}, nil
+}
+
+// runningVersion returns the version all brokers run. Clusters created before the version was
+// tracked in the status fall back to the version the StatefulSet was created with.
+func runningVersion(osc *v1alpha1.OrchestrationCluster, sts *appsv1.StatefulSet) string {
+	if osc.Status.Version != "" {
+		return osc.Status.Version
+	}
+	if sts != nil && sts.Labels[versionLabel] != "" {
+		return sts.Labels[versionLabel]
+	}
+	if osc.Spec.Version != "" {
+		return osc.Spec.Version
+	}
+	return bundles.DefaultVersion
+}
+
+// brokerRejoined returns an error describing why the broker at the given ordinal and all brokers
+// after it are not upgraded and healthy members of the cluster yet.
+func brokerRejoined(
+	ctx context.Context,
+	osc *v1alpha1.OrchestrationCluster,
+	sts *appsv1.StatefulSet,
+	cluster Cluster,
+	ordinal int32,
+) error {
+	if sts == nil {
+		return fmt.Errorf("statefulset not found")
+	}
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return fmt.Errorf("statefulset update not observed yet")
+	}
+	if updated := replicas(sts) - ordinal; sts.Status.UpdatedReplicas < updated {
+		return fmt.Errorf("%d of %d brokers upgraded", sts.Status.UpdatedReplicas, updated)
+	}
+	if sts.Status.ReadyReplicas < replicas(sts) {
+		return fmt.Errorf("%d of %d brokers ready", sts.Status.ReadyReplicas, replicas(sts))
+	}
+
+	topo, err := cluster.Topology(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch topology: %w", err)
+	}
+	return status.TopologyHealthy(osc, topo)
+}
+
+func inProgress(upgrade *v1alpha1.UpgradeStatus, condition metav1.Condition) Result {
+	return Result{
+		Version:        upgrade.ToVersion,
+		RunningVersion: upgrade.FromVersion,
+		Partition:      &upgrade.Partition,
+		Upgrade:        upgrade,
+		Blocked:        condition,
+		RequeueAfter:   PollInterval,
+	}
+}
+
+func replicas(sts *appsv1.StatefulSet) int32 {
+	if sts == nil || sts.Spec.Replicas == nil {
+		return 1
+	}
+	return *sts.Spec.Replicas
+}
+
+func notBlocked(osc *v1alpha1.OrchestrationCluster) metav1.Condition {
+	return metav1.Condition{
+		Type:               v1alpha1.ConditionUpgradeBlocked,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: osc.Generation,
+		Reason:             v1alpha1.ReasonUpgradePathValid,
+		Message:            "no upgrade is blocked",
+	}
+}
+
+func blocked(osc *v1alpha1.OrchestrationCluster, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               v1alpha1.ConditionUpgradeBlocked,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: osc.Generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
@@ -0,0 +1,184 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sijoma/camunda-go-sdk/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/camunda/camunda-operator/api/v1alpha1"
+)
+
+type fakeCluster struct {
+	topology *management.TopologyResponse
+}
+
+func (f *fakeCluster) Topology(_ context.Context) (*management.TopologyResponse, error) {
+	return f.topology, nil
+}
+
+func healthyTopology() *management.TopologyResponse {
+	topo := &management.TopologyResponse{Version: 1}
+	for broker := 0; broker < 3; broker++ {
+		state := management.BrokerState{ID: management.BrokerId(broker), State: management.BrokerStateActive}
+		state.Partitions = []management.PartitionState{{ID: 1, State: management.PartitionStateActive}}
+		topo.Brokers = append(topo.Brokers, state)
+	}
+	return topo
+}
+
+func cluster(specVersion, runningVersion string) *v1alpha1.OrchestrationCluster {
+	return &v1alpha1.OrchestrationCluster{
+		Spec: v1alpha1.OrchestrationClusterSpec{
+			Version:           specVersion,
+			ClusterSize:       3,
+			PartitionCount:    1,
+			ReplicationFactor: 3,
+		},
+		Status: v1alpha1.OrchestrationClusterStatus{Version: runningVersion},
+	}
+}
+
+// statefulSet returns a StatefulSet of three brokers where the given number of brokers is upgraded.
+func statefulSet(updated int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3)},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        3,
+			ReadyReplicas:   3,
+			UpdatedReplicas: updated,
+		},
+	}
+}
+
+func TestStep_NoUpgrade(t *testing.T) {
+	t.Run("new cluster", func(t *testing.T) {
+		result, err := Step(context.Background(), cluster("8.7.7", ""), nil, nil, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, "8.7.7", result.Version)
+		assert.Equal(t, "8.7.7", result.RunningVersion)
+		assert.Nil(t, result.Partition)
+		assert.Nil(t, result.Upgrade)
+		assert.Equal(t, metav1.ConditionFalse, result.Blocked.Status)
+	})
+
+	t.Run("version of existing statefulset", func(t *testing.T) {
+		sts := statefulSet(3)
+		sts.Labels = map[string]string{versionLabel: "8.7.7"}
+
+		result, err := Step(context.Background(), cluster("8.7.7", ""), sts, nil, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, "8.7.7", result.RunningVersion)
+		assert.Nil(t, result.Upgrade)
+	})
+}
+
+func TestStep_InvalidUpgradePath(t *testing.T) {
+	tests := []struct {
+		name string
+		to   string
+	}{
+		{name: "downgrade", to: "8.7.6"},
+		{name: "skipped minor", to: "8.9.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Step(context.Background(), cluster(tt.to, "8.7.7"), statefulSet(3), nil, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, "8.7.7", result.Version, "the running version must be kept")
+			assert.Nil(t, result.Upgrade)
+			assert.Equal(t, metav1.ConditionTrue, result.Blocked.Status)
+			assert.Equal(t, v1alpha1.ReasonInvalidUpgradePath, result.Blocked.Reason)
+		})
+	}
+}
+
+func TestStep_RollingUpgrade(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	fake := &fakeCluster{topology: healthyTopology()}
+	osc := cluster("8.8.0", "8.7.7")
+
+	// The upgrade starts with the broker with the highest ordinal.
+	result, err := Step(ctx, osc, statefulSet(3), fake, now)
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.0", result.Version)
+	assert.Equal(t, "8.7.7", result.RunningVersion)
+	assert.Equal(t, ptr.To[int32](2), result.Partition)
+	assert.Equal(t, PollInterval, result.RequeueAfter)
+	require.NotNil(t, result.Upgrade)
+	assert.Equal(t, "8.7.7", result.Upgrade.FromVersion)
+	assert.Equal(t, "8.8.0", result.Upgrade.ToVersion)
+
+	// The next broker waits until the previous one is upgraded.
+	osc.Status.Upgrade = result.Upgrade
+	result, err = Step(ctx, osc, statefulSet(0), fake, now)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](2), result.Partition)
+
+	// And the topology is healthy again.
+	fake.topology.Brokers[2].Partitions[0].State = management.PartitionStateJoining
+	result, err = Step(ctx, osc, statefulSet(1), fake, now)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](2), result.Partition)
+	assert.Equal(t, metav1.ConditionFalse, result.Blocked.Status)
+
+	fake.topology = healthyTopology()
+	for _, partition := range []int32{1, 0} {
+		result, err = Step(ctx, osc, statefulSet(3-partition-1), fake, now)
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To(partition), result.Partition)
+		osc.Status.Upgrade = result.Upgrade
+	}
+
+	// The upgrade completes once the last broker rejoined.
+	result, err = Step(ctx, osc, statefulSet(3), fake, now)
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.0", result.Version)
+	assert.Equal(t, "8.8.0", result.RunningVersion)
+	assert.Nil(t, result.Partition)
+	assert.Nil(t, result.Upgrade)
+	assert.Zero(t, result.RequeueAfter)
+}
+
+func TestStep_BrokerNotRejoined(t *testing.T) {
+	now := time.Now()
+	fake := &fakeCluster{topology: healthyTopology()}
+	fake.topology.Brokers = fake.topology.Brokers[:2]
+	osc := cluster("8.8.0", "8.7.7")
+	osc.Status.Upgrade = &v1alpha1.UpgradeStatus{
+		FromVersion:     "8.7.7",
+		ToVersion:       "8.8.0",
+		Partition:       2,
+		BrokerUpdatedAt: metav1.NewTime(now.Add(-BrokerRejoinTimeout - time.Second)),
+	}
+
+	result, err := Step(context.Background(), osc, statefulSet(1), fake, now)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](2), result.Partition, "the upgrade must halt")
+	assert.Equal(t, metav1.ConditionTrue, result.Blocked.Status)
+	assert.Equal(t, v1alpha1.ReasonBrokerNotRejoined, result.Blocked.Reason)
+	assert.Contains(t, result.Blocked.Message, "broker 2 did not rejoin")
+}
+
+func TestStep_TargetChangedDuringUpgrade(t *testing.T) {
+	osc := cluster("8.9.0", "8.7.7")
+	osc.Status.Upgrade = &v1alpha1.UpgradeStatus{
+		FromVersion:     "8.7.7",
+		ToVersion:       "8.8.0",
+		Partition:       2,
+		BrokerUpdatedAt: metav1.Now(),
+	}
+
+	result, err := Step(context.Background(), osc, statefulSet(0), &fakeCluster{}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.0", result.Version)
+	assert.Equal(t, v1alpha1.ReasonUpgradeTargetChanged, result.Blocked.Reason)
+}